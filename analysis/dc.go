@@ -0,0 +1,247 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// OperatingPoint is the result of a DC operating point analysis.
+//
+// All element currents use the passive sign convention: the current of an
+// element is the current flowing into its positive terminal, through the
+// element, and out of its negative terminal. The power of an element is
+// therefore positive when it absorbs power, as resistors always do, and
+// negative when it delivers power to the rest of the network.
+type OperatingPoint struct {
+	network  *Network
+	voltages map[*cbo.Net]float64
+	currents map[*Element]float64
+
+	// terminals records the nodes each element is attached to, including
+	// the private nodes created for unconnected terminals.
+	terminals map[*Element][2]*cbo.Net
+}
+
+// SolveDC finds the DC operating point of the receiver using modified nodal
+// analysis.
+//
+// An error is returned if the network has no ground node, or if it does not
+// have a unique solution, which is usually caused by a node that has no
+// DC path to ground, a loop of voltage sources, or current sources in
+// series.
+func (n *Network) SolveDC() (*OperatingPoint, error) {
+	if n.Ground == nil {
+		return nil, errors.New("network has no ground node")
+	}
+	ground := n.node(n.Ground)
+
+	// Each distinct node other than ground gets a row in the system, as
+	// does each voltage source, whose current is an additional unknown.
+	nodeIdx := map[*cbo.Net]int{}
+	var nodes []*cbo.Net
+	var vsources []*Element
+	terminals := make(map[*Element][2]*cbo.Net, len(n.Elements))
+	for _, elem := range n.Elements {
+		var nets [2]*cbo.Net
+		for i, net := range [2]*cbo.Net{elem.Pos, elem.Neg} {
+			if net == nil {
+				// An unconnected terminal is a node of its own.
+				net = &cbo.Net{}
+			} else {
+				net = n.node(net)
+			}
+			nets[i] = net
+			if _, exists := nodeIdx[net]; !exists && net != ground {
+				nodeIdx[net] = len(nodes)
+				nodes = append(nodes, net)
+			}
+		}
+		terminals[elem] = nets
+		if elem.Kind == VoltageSource {
+			vsources = append(vsources, elem)
+		}
+	}
+
+	size := len(nodes) + len(vsources)
+	a := newMatrix(size)
+	z := make([]float64, size)
+	row := func(net *cbo.Net) int {
+		if net == ground {
+			return -1
+		}
+		return nodeIdx[net]
+	}
+
+	vsourceIdx := 0
+	for _, elem := range n.Elements {
+		p, m := row(terminals[elem][0]), row(terminals[elem][1])
+		val := standardValue(elem)
+
+		switch elem.Kind {
+		case Resistor:
+			if val == 0 {
+				return nil, fmt.Errorf("%s: resistance must not be zero", elem.Name)
+			}
+			g := 1 / val
+			a.add(p, p, g)
+			a.add(m, m, g)
+			a.add(p, m, -g)
+			a.add(m, p, -g)
+		case CurrentSource:
+			if p >= 0 {
+				z[p] += val
+			}
+			if m >= 0 {
+				z[m] -= val
+			}
+		case VoltageSource:
+			k := len(nodes) + vsourceIdx
+			vsourceIdx++
+			a.add(p, k, 1)
+			a.add(m, k, -1)
+			a.add(k, p, 1)
+			a.add(k, m, -1)
+			z[k] = val
+		}
+	}
+
+	x, err := a.solve(z)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &OperatingPoint{
+		network:   n,
+		voltages:  make(map[*cbo.Net]float64, len(nodes)+1),
+		currents:  make(map[*Element]float64, len(n.Elements)),
+		terminals: terminals,
+	}
+	op.voltages[ground] = 0
+	for i, net := range nodes {
+		op.voltages[net] = x[i]
+	}
+	vsourceIdx = 0
+	for _, elem := range n.Elements {
+		nets := terminals[elem]
+		switch elem.Kind {
+		case Resistor:
+			op.currents[elem] = (op.voltages[nets[0]] - op.voltages[nets[1]]) / standardValue(elem)
+		case CurrentSource:
+			op.currents[elem] = -standardValue(elem)
+		case VoltageSource:
+			op.currents[elem] = x[len(nodes)+vsourceIdx]
+			vsourceIdx++
+		}
+	}
+
+	return op, nil
+}
+
+// Voltage returns the voltage of the node the given net belongs to,
+// relative to ground. The second return value is false if the net is not
+// attached to any element of the network.
+func (op *OperatingPoint) Voltage(net *cbo.Net) (units.Quantity, bool) {
+	v, ok := op.voltages[op.network.node(net)]
+	if !ok {
+		return units.Quantity{}, false
+	}
+	return units.MakeQuantityFloat(v, units.Volt), true
+}
+
+// Current returns the current through the given element, which must belong
+// to the network that was solved.
+func (op *OperatingPoint) Current(elem *Element) units.Quantity {
+	return units.MakeQuantityFloat(op.currents[elem], units.Ampere)
+}
+
+// Power returns the power absorbed by the given element, which must belong
+// to the network that was solved.
+func (op *OperatingPoint) Power(elem *Element) units.Quantity {
+	v := op.elementVoltage(elem)
+	return units.MakeQuantityFloat(v*op.currents[elem], units.Watt)
+}
+
+func (op *OperatingPoint) elementVoltage(elem *Element) float64 {
+	nets := op.terminals[elem]
+	return op.voltages[nets[0]] - op.voltages[nets[1]]
+}
+
+// standardValue returns the value of the given element in standard units,
+// as a float64.
+func standardValue(elem *Element) float64 {
+	v, _ := elem.Value.Convert(elem.Kind.Unit()).Value().Float64()
+	return v
+}
+
+// matrix is a dense square matrix used for solving the MNA system.
+type matrix [][]float64
+
+func newMatrix(size int) matrix {
+	m := make(matrix, size)
+	for i := range m {
+		m[i] = make([]float64, size)
+	}
+	return m
+}
+
+// add adds v to the element at row i, column j, unless either index is
+// negative, which represents the ground node.
+func (m matrix) add(i, j int, v float64) {
+	if i < 0 || j < 0 {
+		return
+	}
+	m[i][j] += v
+}
+
+// solve solves the system m x = z using Gaussian elimination with partial
+// pivoting. Both m and z are modified in the process.
+func (m matrix) solve(z []float64) ([]float64, error) {
+	size := len(m)
+
+	var scale float64
+	for _, row := range m {
+		for _, v := range row {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	epsilon := scale * 1e-12
+
+	for col := 0; col < size; col++ {
+		pivot := col
+		for r := col + 1; r < size; r++ {
+			if math.Abs(m[r][col]) > math.Abs(m[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(m[pivot][col]) <= epsilon {
+			return nil, errors.New("network has no unique DC solution; check for nodes with no DC path to ground, loops of voltage sources, or current sources in series")
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		z[col], z[pivot] = z[pivot], z[col]
+
+		for r := col + 1; r < size; r++ {
+			f := m[r][col] / m[col][col]
+			if f == 0 {
+				continue
+			}
+			for c := col; c < size; c++ {
+				m[r][c] -= f * m[col][c]
+			}
+			z[r] -= f * z[col]
+		}
+	}
+
+	x := make([]float64, size)
+	for r := size - 1; r >= 0; r-- {
+		sum := z[r]
+		for c := r + 1; c < size; c++ {
+			sum -= m[r][c] * x[c]
+		}
+		x[r] = sum / m[r][r]
+	}
+	return x, nil
+}
@@ -0,0 +1,184 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+func TestSolveDCDivider(t *testing.T) {
+	// A 10V source across a 10k/5k divider, with the bottom resistor inside
+	// a nested circuit instance so that the output node spans two nets.
+	v1 := testDevice("V1", "voltage_source", units.MakeQuantityInt(10, units.Volt))
+	r1 := testDevice("R1", "resistor", units.MakeQuantityInt(10, units.ByName("kohm")))
+	r2 := testDevice("R2", "resistor", units.MakeQuantityInt(5000, units.Ohm))
+
+	lower := &cbo.Circuit{
+		Name: "Lower",
+		Terminals: cbo.TerminalsDef{
+			All: map[string]cbo.Terminal{
+				"OUT": {Name: "OUT"},
+				"GND": {Name: "GND"},
+			},
+			Names: []string{"OUT", "GND"},
+		},
+	}
+	sub := lower.NewInstance("lower", nil)
+	sub.Devices = map[string]*cbo.DeviceInstance{"R2": r2}
+
+	root := &cbo.CircuitInstance{
+		Circuit: &cbo.Circuit{Name: "Divider"},
+		Name:    "divider",
+		Devices: map[string]*cbo.DeviceInstance{
+			"V1": v1,
+			"R1": r1,
+		},
+		Circuits: map[string]*cbo.CircuitInstance{
+			"lower": sub,
+		},
+	}
+
+	connect(v1.Terminals["P"].Outside[0], r1.Terminals["P"].Outside[0])
+	out := connect(r1.Terminals["N"].Outside[0], sub.Terminals["OUT"].Outside[0])
+	inner := connect(sub.Terminals["OUT"].Inside[0], r2.Terminals["P"].Outside[0])
+	connect(v1.Terminals["N"].Outside[0], sub.Terminals["GND"].Outside[0])
+	connect(sub.Terminals["GND"].Inside[0], r2.Terminals["N"].Outside[0])
+
+	network, err := FromDesign(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if network.Ground == nil {
+		t.Fatalf("no ground selected")
+	}
+
+	op, err := network.SolveDC()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, net := range []*cbo.Net{out, inner} {
+		v, ok := op.Voltage(net)
+		if !ok {
+			t.Fatalf("no voltage for output net")
+		}
+		assertQuantity(t, "output voltage", v, 10.0/3, units.Volt)
+	}
+
+	r1Elem := network.Element("R1")
+	assertQuantity(t, "R1 current", op.Current(r1Elem), 10.0/15000, units.Ampere)
+	assertQuantity(t, "R1 power", op.Power(r1Elem), math.Pow(10.0/15000, 2)*10000, units.Watt)
+
+	v1Elem := network.Element("V1")
+	assertQuantity(t, "V1 current", op.Current(v1Elem), -10.0/15000, units.Ampere)
+	assertQuantity(t, "V1 power", op.Power(v1Elem), -10*10.0/15000, units.Watt)
+}
+
+func TestSolveDCCurrentSource(t *testing.T) {
+	gnd := &cbo.Net{}
+	top := &cbo.Net{}
+	i1 := &Element{
+		Name:  "I1",
+		Kind:  CurrentSource,
+		Value: units.MakeQuantityInt(20, units.ByName("mA")),
+		Pos:   top,
+		Neg:   gnd,
+	}
+	r1 := &Element{
+		Name:  "R1",
+		Kind:  Resistor,
+		Value: units.MakeQuantityInt(100, units.Ohm),
+		Pos:   top,
+		Neg:   gnd,
+	}
+	network := &Network{
+		Elements: []*Element{i1, r1},
+		Ground:   gnd,
+	}
+
+	op, err := network.SolveDC()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, _ := op.Voltage(top)
+	assertQuantity(t, "top voltage", v, 2, units.Volt)
+	assertQuantity(t, "R1 current", op.Current(r1), 0.02, units.Ampere)
+	assertQuantity(t, "I1 power", op.Power(i1), -0.04, units.Watt)
+}
+
+func TestSolveDCErrors(t *testing.T) {
+	gnd := &cbo.Net{}
+	a := &cbo.Net{}
+	b := &cbo.Net{}
+
+	t.Run("no ground", func(t *testing.T) {
+		network := &Network{}
+		_, err := network.SolveDC()
+		if err == nil {
+			t.Fatalf("succeeded; want error")
+		}
+	})
+
+	t.Run("floating node", func(t *testing.T) {
+		network := &Network{
+			Elements: []*Element{
+				{Name: "V1", Kind: VoltageSource, Value: units.MakeQuantityInt(5, units.Volt), Pos: a, Neg: gnd},
+				{Name: "I1", Kind: CurrentSource, Value: units.MakeQuantityInt(1, units.Ampere), Pos: b, Neg: a},
+			},
+			Ground: gnd,
+		}
+		_, err := network.SolveDC()
+		if err == nil {
+			t.Fatalf("succeeded; want error")
+		}
+	})
+}
+
+func testDevice(name, kind string, value units.Quantity) *cbo.DeviceInstance {
+	termNames := []string{"P", "N"}
+	dev := &cbo.Device{
+		Name: kind,
+		Terminals: cbo.TerminalsDef{
+			All:   map[string]cbo.Terminal{},
+			Names: termNames,
+		},
+	}
+	terms := map[string]*cbo.TerminalInstance{}
+	for _, tn := range termNames {
+		term := cbo.Terminal{Name: tn}
+		dev.Terminals.All[tn] = term
+		terms[tn] = term.NewInstance()
+	}
+	return &cbo.DeviceInstance{
+		Device: dev,
+		Name:   name,
+		Attrs: map[string]cbo.Any{
+			IdealAttr: kind,
+			"value":   value,
+		},
+		Terminals: terms,
+	}
+}
+
+func connect(eps ...*cbo.Endpoint) *cbo.Net {
+	net := &cbo.Net{Endpoints: cbo.EndpointSet{}}
+	for _, ep := range eps {
+		net.Connect(ep)
+	}
+	return net
+}
+
+func assertQuantity(t *testing.T, what string, got units.Quantity, want float64, unit *units.Unit) {
+	t.Helper()
+	if !got.ConvertableTo(unit) {
+		t.Errorf("%s has unit %s; want %s", what, got.Unit(), unit)
+		return
+	}
+	v, _ := got.Convert(unit).Value().Float64()
+	if math.Abs(v-want) > math.Abs(want)*1e-9+1e-15 {
+		t.Errorf("%s is %g; want %g", what, v, want)
+	}
+}
@@ -0,0 +1,17 @@
+// Package analysis contains simple circuit analyses that can be performed
+// directly on an evaluated Cirbo design, without exporting it to an
+// external simulator.
+//
+// Currently the only analysis is a DC operating point solver for linear
+// networks of ideal resistors, voltage sources and current sources, which
+// uses modified nodal analysis. It is intended for quick sanity checks such
+// as divider outputs, LED currents and resistor power dissipation, rather
+// than as a replacement for a full simulator such as ngspice.
+//
+// A device takes part in the analysis if it has a string attribute named
+// "ideal" whose value is "resistor", "voltage_source" or "current_source".
+// The element's value is taken from the device's attribute of type
+// Resistance, Voltage or Current respectively, and its positive and
+// negative nodes are the first two terminals of the device, in declaration
+// order. All other devices are ignored.
+package analysis
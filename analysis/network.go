@@ -0,0 +1,193 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// IdealAttr is the name of the reserved device attribute that marks a
+// device as an ideal element for the purpose of analysis.
+const IdealAttr = "ideal"
+
+// ElementKind is an enumeration of the kinds of ideal element that can be
+// included in a Network.
+type ElementKind rune
+
+const (
+	// Resistor is an ideal linear resistor whose value is a resistance.
+	Resistor ElementKind = 'R'
+
+	// VoltageSource is an ideal DC voltage source whose value is the
+	// voltage of its positive node relative to its negative node.
+	VoltageSource ElementKind = 'V'
+
+	// CurrentSource is an ideal DC current source whose value is the
+	// current it drives out of its positive node, through the rest of the
+	// network, and back into its negative node.
+	CurrentSource ElementKind = 'I'
+)
+
+// elementKinds maps the values accepted in IdealAttr to element kinds.
+var elementKinds = map[string]ElementKind{
+	"resistor":       Resistor,
+	"voltage_source": VoltageSource,
+	"current_source": CurrentSource,
+}
+
+// Unit returns the unit that an element of the receiving kind has its
+// value expressed in.
+func (k ElementKind) Unit() *units.Unit {
+	switch k {
+	case Resistor:
+		return units.Ohm
+	case VoltageSource:
+		return units.Volt
+	case CurrentSource:
+		return units.Ampere
+	default:
+		// should never happen if the caller is using the kind constants
+		panic(fmt.Errorf("unsupported element kind %q", k))
+	}
+}
+
+// Element is an ideal two-terminal element in a Network.
+type Element struct {
+	Name  string
+	Kind  ElementKind
+	Value units.Quantity
+
+	// Pos and Neg are the nets attached to the positive and negative
+	// terminals of the element. Either may be nil if that terminal is not
+	// connected to anything.
+	Pos, Neg *cbo.Net
+}
+
+// Network is a linear network of ideal elements to be analysed.
+type Network struct {
+	Elements []*Element
+
+	// Nodes maps each net in the network to a representative net, such
+	// that nets with the same representative are treated as the same node.
+	// This is usually the result of cbo.CircuitInstance.FlatNets. Nets not
+	// present in the map are their own representative.
+	Nodes map[*cbo.Net]*cbo.Net
+
+	// Ground is a net that is taken as the zero-volt reference node.
+	Ground *cbo.Net
+}
+
+// FromDesign builds a Network from the ideal devices within the given root
+// circuit instance and all of the circuit instances nested within it.
+//
+// Elements are named after the instance path of their devices. If the
+// design contains a net whose suggested name is GND then that net is
+// selected as the ground node; otherwise the caller must set Ground on the
+// result before solving.
+//
+// An error is returned if a device has an invalid ideal element kind, lacks
+// a value of the appropriate type, or has fewer than two terminals.
+func FromDesign(root *cbo.CircuitInstance) (*Network, error) {
+	ret := &Network{
+		Nodes: root.FlatNets(),
+	}
+
+	var err error
+	root.WalkDevices(func(path cbo.InstancePath, inst *cbo.DeviceInstance) {
+		if err != nil {
+			return
+		}
+		var elem *Element
+		elem, err = deviceElement(path, inst)
+		if elem != nil {
+			ret.Elements = append(ret.Elements, elem)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for net := range ret.Nodes {
+		if net.SuggestedName() == "GND" {
+			ret.Ground = net
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+// deviceElement returns the element for the given device instance, or nil
+// if the device is not marked as an ideal element.
+func deviceElement(path cbo.InstancePath, inst *cbo.DeviceInstance) (*Element, error) {
+	kindName, ok := inst.Attrs[IdealAttr].(string)
+	if !ok {
+		return nil, nil
+	}
+	kind, ok := elementKinds[kindName]
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported ideal element kind %q", path, kindName)
+	}
+
+	unit := kind.Unit()
+	val, ok := quantityAttr(inst, unit)
+	if !ok {
+		return nil, fmt.Errorf("%s: ideal %s has no attribute of a type commensurable with %s", path, kindName, unit)
+	}
+
+	var eps []*cbo.Endpoint
+	for _, termName := range inst.TerminalNames() {
+		eps = append(eps, inst.Terminals[termName].Outside...)
+	}
+	if len(eps) < 2 {
+		return nil, fmt.Errorf("%s: ideal %s must have at least two terminals", path, kindName)
+	}
+
+	return &Element{
+		Name:  path.String(),
+		Kind:  kind,
+		Value: val,
+		Pos:   eps[0].Net,
+		Neg:   eps[1].Net,
+	}, nil
+}
+
+// quantityAttr returns the value of the device's first attribute, in
+// lexical order by name, that is a quantity commensurable with the given
+// unit.
+func quantityAttr(inst *cbo.DeviceInstance, unit *units.Unit) (units.Quantity, bool) {
+	names := make([]string, 0, len(inst.Attrs))
+	for name := range inst.Attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		q, ok := inst.Attrs[name].(units.Quantity)
+		if ok && q.ConvertableTo(unit) {
+			return q, true
+		}
+	}
+	return units.Quantity{}, false
+}
+
+// node returns the representative net for the given net.
+func (n *Network) node(net *cbo.Net) *cbo.Net {
+	if rep, exists := n.Nodes[net]; exists {
+		return rep
+	}
+	return net
+}
+
+// Element returns the element with the given name, or nil if there is no
+// such element.
+func (n *Network) Element(name string) *Element {
+	for _, elem := range n.Elements {
+		if elem.Name == name {
+			return elem
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package ast
+
+import (
+	"github.com/cirbo-lang/cirbo/source"
+)
+
+// Conversion is an expression that re-expresses a quantity in a particular
+// unit, written as e.g. width as mil.
+type Conversion struct {
+	WithRange
+	Operand Node
+	Unit    string
+
+	UnitRange source.Range
+}
+
+func (n *Conversion) walkChildNodes(cb internalWalkFunc) {
+	cb(n.Operand)
+}
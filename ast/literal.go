@@ -10,6 +10,31 @@ type NumberLit struct {
 	WithRange
 	Value *big.Float
 	Unit  string // empty for dimensionless values
+
+	// Imaginary is true if the value was marked as imaginary with a j
+	// suffix, such as in 30j ohm, in which case Value is the magnitude of
+	// the imaginary part.
+	Imaginary bool
+
+	// Tolerance is the tolerance given for the value, such as ±1% in
+	// 10kohm ±1%, or nil if the value is exact.
+	Tolerance *Tolerance
+}
+
+// Tolerance is the tolerance of a NumberLit, giving how far the actual value
+// may lie below and above the nominal value.
+type Tolerance struct {
+	// Minus and Plus are the magnitudes of the lower and upper tolerance,
+	// which are equal for a symmetric tolerance such as ±1%.
+	Minus *big.Float
+	Plus  *big.Float
+
+	// Relative is true if the tolerance was given as a percentage, in which
+	// case Minus and Plus are fractions of the nominal value. Otherwise they
+	// are quantities in the given Unit, which is empty for dimensionless
+	// values.
+	Relative bool
+	Unit     string
 }
 
 func (n *NumberLit) walkChildNodes(cb internalWalkFunc) {
@@ -25,6 +50,8 @@ func (n *NumberLit) walkChildNodes(cb internalWalkFunc) {
 //
 // If the unit string is empty, the result is a dimensionless quantity
 // which can then serve as a plain number for calculations.
+//
+// Any tolerance is ignored, so the result is the nominal value.
 func (n *NumberLit) Quantity() units.Quantity {
 	if n.Unit == "" {
 		return units.MakeDimensionless(n.Value)
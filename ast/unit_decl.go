@@ -0,0 +1,36 @@
+package ast
+
+// UnitDecl is an AST node that represents the declaration of a unit of
+// measure, such as:
+//
+//     unit oz_cu = 34.79um;
+//
+// The value is usually a quantity giving the magnitude of the new unit, but
+// it may also be an existing unit, such as one imported from another
+// package, in which case the new name is an alias for that unit.
+type UnitDecl struct {
+	WithRange
+	Name  string
+	Value Node
+}
+
+func (n *UnitDecl) walkChildNodes(cb internalWalkFunc) {
+	cb(n.Value)
+}
+
+// DimensionDecl is an AST node that represents the declaration of a name for
+// the type of quantities of a particular dimensionality, such as:
+//
+//     dimension ThermalConductance = 1W / 1K;
+//
+// The value is either a quantity of the required dimensionality or an
+// existing number type.
+type DimensionDecl struct {
+	WithRange
+	Name  string
+	Value Node
+}
+
+func (n *DimensionDecl) walkChildNodes(cb internalWalkFunc) {
+	cb(n.Value)
+}
@@ -0,0 +1,187 @@
+package cbo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Bundle describes an interface bundle, which is a named group of terminals
+// that are conventionally connected together as a unit, such as the SCLK,
+// MOSI, MISO and CS signals of an SPI interface.
+//
+// The language does not yet have syntax for declaring bundles or for
+// connecting them with a single statement such as MCU.spi -- FLASH.spi,
+// because connection statements are not yet compiled. Until then, bundles
+// must be connected with ConnectBundles by whatever code builds the circuit
+// objects.
+type Bundle struct {
+	Name    string
+	Members []BundleMember
+}
+
+// BundleMember describes one of the terminals in a bundle.
+type BundleMember struct {
+	Name string
+
+	// Role is the role of the member terminal on the leading side of the
+	// bundle. The following side has the inverse role. Members with NoRole,
+	// such as a shared ground, have no role on either side.
+	Role TerminalRole
+}
+
+// BundleInstance is a particular set of endpoints playing the members of a
+// bundle, such as the SPI terminals of a particular microcontroller.
+type BundleInstance struct {
+	Bundle *Bundle
+
+	// Name identifies the instance in error messages, such as "MCU.spi".
+	Name string
+
+	// Members are the endpoints for each member of the bundle, keyed by
+	// member name. Bus members have more than one endpoint.
+	Members map[string][]*Endpoint
+}
+
+// NewInstance creates an instance of the receiver from the outside
+// endpoints of the given terminal instances, keyed by member name.
+func (b *Bundle) NewInstance(name string, terms map[string]*TerminalInstance) *BundleInstance {
+	members := make(map[string][]*Endpoint, len(terms))
+	for memberName, term := range terms {
+		members[memberName] = term.Outside
+	}
+	return &BundleInstance{
+		Bundle:  b,
+		Name:    name,
+		Members: members,
+	}
+}
+
+// BundleMismatch describes a member of a bundle that could not be
+// connected.
+type BundleMismatch struct {
+	// Member is the name of the member that could not be connected, or
+	// empty if the bundles themselves are incompatible.
+	Member string
+	Reason string
+}
+
+func (m BundleMismatch) Error() string {
+	if m.Member == "" {
+		return m.Reason
+	}
+	return fmt.Sprintf("member %s %s", m.Member, m.Reason)
+}
+
+// ConnectBundles connects each member of the first given bundle instance
+// to the same member of the second.
+//
+// For members that have a role, the two sides must have opposite roles: the
+// leading side of one instance connects to the following side of the other.
+// Members that are missing from either side, that have different bus
+// widths, or whose roles do not complement each other are not connected and
+// are instead returned as mismatches. All other members are connected
+// regardless of any mismatches, but a member whose nets conflict, such as
+// by having different names, is also returned as a mismatch.
+//
+// The result is nil if all members were connected without conflict.
+func ConnectBundles(a, b *BundleInstance) []BundleMismatch {
+	if a.Bundle != b.Bundle {
+		return []BundleMismatch{
+			{
+				Reason: fmt.Sprintf("cannot connect %s bundle %s to %s bundle %s", a.Bundle.Name, a.Name, b.Bundle.Name, b.Name),
+			},
+		}
+	}
+
+	var ret []BundleMismatch
+	known := make(map[string]bool, len(a.Bundle.Members))
+
+	for _, member := range a.Bundle.Members {
+		known[member.Name] = true
+		aEps, bEps := a.Members[member.Name], b.Members[member.Name]
+
+		switch {
+		case len(aEps) == 0 && len(bEps) == 0:
+			ret = append(ret, BundleMismatch{
+				Member: member.Name,
+				Reason: fmt.Sprintf("is not present on %s or %s", a.Name, b.Name),
+			})
+			continue
+		case len(aEps) == 0:
+			ret = append(ret, BundleMismatch{
+				Member: member.Name,
+				Reason: fmt.Sprintf("is not present on %s", a.Name),
+			})
+			continue
+		case len(bEps) == 0:
+			ret = append(ret, BundleMismatch{
+				Member: member.Name,
+				Reason: fmt.Sprintf("is not present on %s", b.Name),
+			})
+			continue
+		case len(aEps) != len(bEps):
+			ret = append(ret, BundleMismatch{
+				Member: member.Name,
+				Reason: fmt.Sprintf("has width %d on %s but %d on %s", len(aEps), a.Name, len(bEps), b.Name),
+			})
+			continue
+		}
+
+		if member.Role != NoRole {
+			aRole, bRole := aEps[0].Role, bEps[0].Role
+			if aRole == NoRole || bRole != aRole.Inverse() {
+				ret = append(ret, BundleMismatch{
+					Member: member.Name,
+					Reason: fmt.Sprintf("is %s on %s and %s on %s, but one side must lead and the other follow", aRole, a.Name, bRole, b.Name),
+				})
+				continue
+			}
+		}
+
+		for i := range aEps {
+			if err := joinEndpoints(aEps[i], bEps[i]); err != nil {
+				ret = append(ret, BundleMismatch{
+					Member: member.Name,
+					Reason: fmt.Sprintf("has conflicting nets: %s", err),
+				})
+			}
+		}
+	}
+
+	// Any members present in the instances but not in the bundle itself
+	// indicate a mistake in how the instances were constructed.
+	var extra []string
+	for _, inst := range []*BundleInstance{a, b} {
+		for name := range inst.Members {
+			if !known[name] {
+				extra = append(extra, name)
+				known[name] = true
+			}
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		ret = append(ret, BundleMismatch{
+			Member: name,
+			Reason: fmt.Sprintf("is not a member of bundle %s", a.Bundle.Name),
+		})
+	}
+
+	return ret
+}
+
+// joinEndpoints ensures that the two given endpoints belong to the same net,
+// creating a new net if neither belongs to one yet. An error is returned if
+// the endpoints belong to nets that cannot be merged; see Net.Connect.
+func joinEndpoints(a, b *Endpoint) error {
+	switch {
+	case a.Net != nil:
+		return a.Net.Connect(b)
+	case b.Net != nil:
+		return b.Net.Connect(a)
+	default:
+		net := &Net{Endpoints: EndpointSet{}}
+		net.Connect(a)
+		return net.Connect(b)
+	}
+}
@@ -0,0 +1,87 @@
+package cbo
+
+import (
+	"testing"
+)
+
+func TestConnectBundles(t *testing.T) {
+	spi := &Bundle{
+		Name: "SPI",
+		Members: []BundleMember{
+			{Name: "SCLK", Role: Leader},
+			{Name: "MOSI", Role: Leader},
+			{Name: "MISO", Role: Leader},
+			{Name: "CS", Role: Leader},
+		},
+	}
+	terms := func(role TerminalRole, names ...string) map[string]*TerminalInstance {
+		ret := make(map[string]*TerminalInstance, len(names))
+		for _, name := range names {
+			term := &Terminal{Name: name, Role: role}
+			ret[name] = term.NewInstance()
+		}
+		return ret
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		mcu := spi.NewInstance("MCU.spi", terms(Leader, "SCLK", "MOSI", "MISO", "CS"))
+		flash := spi.NewInstance("FLASH.spi", terms(Follower, "SCLK", "MOSI", "MISO", "CS"))
+
+		if got := ConnectBundles(mcu, flash); got != nil {
+			t.Fatalf("unexpected mismatches: %#v", got)
+		}
+
+		for _, member := range spi.Members {
+			a, b := mcu.Members[member.Name][0], flash.Members[member.Name][0]
+			if a.Net == nil || a.Net != b.Net {
+				t.Errorf("member %s not connected", member.Name)
+			}
+		}
+		if mcu.Members["SCLK"][0].Net == mcu.Members["MOSI"][0].Net {
+			t.Errorf("members SCLK and MOSI connected to each other")
+		}
+
+		// Connecting again must leave the nets intact.
+		if got := ConnectBundles(mcu, flash); got != nil {
+			t.Fatalf("unexpected mismatches on reconnect: %#v", got)
+		}
+		if net := mcu.Members["CS"][0].Net; len(net.Endpoints) != 2 {
+			t.Errorf("net has %d endpoints after reconnect; want 2", len(net.Endpoints))
+		}
+	})
+
+	t.Run("mismatched", func(t *testing.T) {
+		mcuTerms := terms(Leader, "SCLK", "MOSI", "CS", "IRQ")
+		mcuTerms["MISO"] = (&Terminal{Name: "MISO", Role: Leader, UpperBound: 1}).NewInstance()
+		mcu := spi.NewInstance("MCU.spi", mcuTerms)
+		other := spi.NewInstance("U2.spi", terms(Leader, "SCLK", "MOSI", "MISO"))
+
+		got := ConnectBundles(mcu, other)
+		want := []string{
+			"member SCLK is Leader on MCU.spi and Leader on U2.spi, but one side must lead and the other follow",
+			"member MOSI is Leader on MCU.spi and Leader on U2.spi, but one side must lead and the other follow",
+			"member MISO has width 2 on MCU.spi but 1 on U2.spi",
+			"member CS is not present on U2.spi",
+			"member IRQ is not a member of bundle SPI",
+		}
+		if len(got) != len(want) {
+			t.Fatalf("wrong number of mismatches %d; want %d\n%#v", len(got), len(want), got)
+		}
+		for i := range want {
+			if got[i].Error() != want[i] {
+				t.Errorf("wrong mismatch %d\ngot:  %s\nwant: %s", i, got[i], want[i])
+			}
+		}
+		if mcu.Members["SCLK"][0].Net != nil {
+			t.Errorf("mismatched member SCLK was connected")
+		}
+	})
+
+	t.Run("different bundles", func(t *testing.T) {
+		i2c := &Bundle{Name: "I2C"}
+		got := ConnectBundles(spi.NewInstance("MCU.spi", nil), i2c.NewInstance("U3.i2c", nil))
+		if len(got) != 1 || got[0].Member != "" {
+			t.Fatalf("wrong mismatches %#v", got)
+		}
+	})
+}
@@ -46,6 +46,16 @@ type CircuitInstance struct {
 	Circuits  map[string]*CircuitInstance
 }
 
+// TerminalNames returns the names of the receiver's terminals, in the order
+// they were declared in the circuit where possible.
+func (ci *CircuitInstance) TerminalNames() []string {
+	var def TerminalsDef
+	if ci.Circuit != nil {
+		def = ci.Circuit.Terminals
+	}
+	return terminalInstanceNames(def, ci.Terminals)
+}
+
 // CircuitsDef represents the circuits instantiated in a particular context.
 //
 // The keys of this map are the symbol names to be used for circuit instances
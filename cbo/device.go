@@ -10,6 +10,16 @@ type Device struct {
 	Name      string
 	Attrs     AttributesDef
 	Terminals TerminalsDef
+
+	// ERCOnly is set for pseudo-devices that exist only to customize the
+	// behavior of the electrical rules checker, such as no-connect flags.
+	// Such devices have no physical counterpart and so must be excluded from
+	// netlists and bills of materials.
+	//
+	// All of the terminals of an ERC-only device are considered to be
+	// electrically connected to one another, so that a flag device placed
+	// in series between two nets joins them into a single node.
+	ERCOnly bool
 }
 
 type DeviceInstance struct {
@@ -19,3 +29,13 @@ type DeviceInstance struct {
 	Attrs      map[string]Any
 	Terminals  map[string]*TerminalInstance
 }
+
+// TerminalNames returns the names of the receiver's terminals, in the order
+// they were declared in the device where possible.
+func (di *DeviceInstance) TerminalNames() []string {
+	var def TerminalsDef
+	if di.Device != nil {
+		def = di.Device.Terminals
+	}
+	return terminalInstanceNames(def, di.Terminals)
+}
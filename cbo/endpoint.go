@@ -10,6 +10,10 @@ type Endpoint struct {
 	Net  *Net
 	ERC  ERCMode
 
+	// Role is the role of the terminal the endpoint belongs to, from the
+	// perspective of the endpoint's net.
+	Role TerminalRole
+
 	// Passthrough, if non-nil, is a set of endpoints that "pass through"
 	// ERC characteristics.
 	//
@@ -21,6 +25,16 @@ type Endpoint struct {
 	// When set, the endpoint's own ERC is ignored and one is instead inferred
 	// by combining the ERC modes arriving on "the other side".
 	Passthrough EndpointSet
+
+	// Voltage, if non-nil, describes the voltage characteristics of the
+	// endpoint. It is set only on the outside endpoints of terminals, since
+	// the voltages seen on the inside of a circuit depend on what the
+	// circuit is connected to.
+	Voltage *VoltageSpec
+
+	// Waivers are any electrical rules waived for the net the endpoint
+	// belongs to.
+	Waivers []Waiver
 }
 
 // An EndpointSet is a set of endpoints.
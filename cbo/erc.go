@@ -53,6 +53,17 @@ const (
 	// endpoints, one of which is this flag and the other is the endpoint that
 	// is intentionally not connected.
 	NoConnectFlag ERCDir = 'Ⓝ'
+
+	// BusKeeperFlag is a special "direction" that represents that a net is
+	// held at its last driven level by a bus keeper when none of its
+	// tristate outputs are driving it, and so it does not require a pull-up
+	// or pull-down resistor.
+	BusKeeperFlag ERCDir = 'Ⓚ'
+
+	// MultiLeaderFlag is a special "direction" that represents that a net
+	// is intentionally shared by multiple Leader endpoints, such as on a
+	// multi-master bus, where that would usually be an error.
+	MultiLeaderFlag ERCDir = 'Ⓛ'
 )
 
 type ERCOutputType rune
@@ -9,7 +9,7 @@ const (
 	_ERCDir_name_1 = "Bidirectional"
 	_ERCDir_name_2 = "Input"
 	_ERCDir_name_3 = "Output"
-	_ERCDir_name_4 = "MultiOutputSinkFlagNoConnectFlag"
+	_ERCDir_name_4 = "BusKeeperFlagMultiLeaderFlagMultiOutputSinkFlagNoConnectFlag"
 )
 
 var (
@@ -17,7 +17,7 @@ var (
 	_ERCDir_index_1 = [...]uint8{0, 13}
 	_ERCDir_index_2 = [...]uint8{0, 5}
 	_ERCDir_index_3 = [...]uint8{0, 6}
-	_ERCDir_index_4 = [...]uint8{0, 19, 32}
+	_ERCDir_index_4 = [...]uint8{0, 13, 28, 47, 60}
 )
 
 func (i ERCDir) String() string {
@@ -30,8 +30,8 @@ func (i ERCDir) String() string {
 		return _ERCDir_name_2
 	case i == 79:
 		return _ERCDir_name_3
-	case 9410 <= i && i <= 9411:
-		i -= 9410
+	case 9408 <= i && i <= 9411:
+		i -= 9408
 		return _ERCDir_name_4[_ERCDir_index_4[i]:_ERCDir_index_4[i+1]]
 	default:
 		return fmt.Sprintf("ERCDir(%d)", i)
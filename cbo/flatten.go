@@ -0,0 +1,99 @@
+package cbo
+
+// FlatNets returns a map from each net connected to an endpoint anywhere in
+// the receiver's hierarchy to a representative net for the group of nets
+// that are joined together through the terminals of nested circuit
+// instances.
+//
+// Each net inside a circuit instance is distinct from the net its terminals
+// are connected to on the outside, but electrically they are the same node.
+// Callers that need a flat view of the design, such as exporters and
+// simulators, can use the result to treat each group as a single node.
+//
+// The nets attached to the terminals of an ERC-only device are also joined,
+// since such devices have no physical counterpart, as are global nets that
+// share the same name.
+//
+// The root circuit's own terminals have nothing on the outside, so they do
+// not join any nets. Which net in each group is chosen as representative is
+// unspecified.
+func (ci *CircuitInstance) FlatNets() map[*Net]*Net {
+	parent := map[*Net]*Net{}
+
+	var find func(net *Net) *Net
+	find = func(net *Net) *Net {
+		p, exists := parent[net]
+		if !exists {
+			parent[net] = net
+			return net
+		}
+		if p == net {
+			return net
+		}
+		rep := find(p)
+		parent[net] = rep
+		return rep
+	}
+	addEndpoint := func(ep *Endpoint) {
+		if ep.Net != nil {
+			find(ep.Net)
+		}
+	}
+	join := func(a, b *Net) {
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			parent[rb] = ra
+		}
+	}
+
+	ci.WalkCircuits(func(path InstancePath, inst *CircuitInstance) {
+		for _, term := range inst.Terminals {
+			for i, inside := range term.Inside {
+				addEndpoint(inside)
+				if len(path) == 0 || i >= len(term.Outside) {
+					continue
+				}
+				outside := term.Outside[i]
+				addEndpoint(outside)
+				if inside.Net != nil && outside.Net != nil {
+					join(inside.Net, outside.Net)
+				}
+			}
+		}
+		for _, dev := range inst.Devices {
+			ercOnly := dev.Device != nil && dev.Device.ERCOnly
+			var first *Net
+			for _, term := range dev.Terminals {
+				for _, ep := range term.Outside {
+					addEndpoint(ep)
+					if !ercOnly || ep.Net == nil {
+						continue
+					}
+					if first == nil {
+						first = ep.Net
+					} else {
+						join(first, ep.Net)
+					}
+				}
+			}
+		}
+	})
+
+	globals := map[string]*Net{}
+	for net := range parent {
+		if !net.Global || net.Name == "" {
+			continue
+		}
+		if first, exists := globals[net.Name]; exists {
+			join(first, net)
+		} else {
+			globals[net.Name] = net
+		}
+	}
+
+	ret := make(map[*Net]*Net, len(parent))
+	for net := range parent {
+		ret[net] = find(net)
+	}
+	return ret
+}
@@ -1,8 +1,39 @@
 package cbo
 
+import (
+	"fmt"
+)
+
+// Net is a set of endpoints that are electrically connected together.
+//
+// The language does not yet have syntax for declaring named or global nets,
+// because the bodies of circuits, where nets are created, are not yet
+// compiled. Until then, Name and Global must be set by whatever code builds
+// the circuit objects.
 type Net struct {
 	Endpoints EndpointSet
 
+	// Name, if non-empty, is a name chosen by the user for the net. It
+	// always takes priority over any name suggested from the net's
+	// endpoints.
+	Name string
+
+	// Global, if set, indicates that the net is a global rail that is
+	// joined with every other global net of the same name anywhere in the
+	// design, such as GND or +3V3. Global nets must have a Name.
+	Global bool
+
+	// Waivers are any electrical rules waived for the net as a whole.
+	Waivers []Waiver
+
+	// Class, if non-nil, is the net class that gives the routing
+	// constraints for the net.
+	Class *NetClass
+
+	// DiffPair, if non-nil, is the differential pair that the net is one
+	// half of.
+	DiffPair *DiffPair
+
 	onReplace []func(new *Net)
 }
 
@@ -12,11 +43,28 @@ type Net struct {
 // receiver, causing the receiver to have the superset of both endpoint sets
 // and the old net to have no endpoints at all. The old net should, at that
 // point, be discarded entirely.
-func (n *Net) Connect(e *Endpoint) {
+//
+// When nets are merged, the receiver keeps its own name, net class and
+// differential pair if it has them, and adopts those of the other net
+// otherwise. The merged net is global if either of the two nets was.
+//
+// Since the user chooses net names, two nets with different names cannot
+// be merged without discarding one of them, and likewise for net classes
+// and differential pairs. In that case the nets are merged anyway, keeping
+// the receiver's attributes, and an error is returned so that the conflict
+// can be reported. Connecting the two nets of a differential pair to each
+// other is also an error.
+func (n *Net) Connect(e *Endpoint) error {
+	if e.Net == n {
+		// Already connected
+		return nil
+	}
+
 	// If the given endpoint already has a net then we need to merge the two
 	// nets together, collecting any other endpoints already associated with
 	// the other net. The other net will be empty after we are done.
 	if e.Net != nil {
+		var err error
 		mn := e.Net
 		otherEs := mn.Endpoints.List()
 		for _, otherE := range otherEs {
@@ -24,6 +72,33 @@ func (n *Net) Connect(e *Endpoint) {
 			otherE.Net = n
 			mn.Endpoints.Remove(otherE)
 		}
+		n.Waivers = append(n.Waivers, mn.Waivers...)
+		mn.Waivers = nil
+		switch {
+		case n.Name == "":
+			n.Name = mn.Name
+		case mn.Name != "" && mn.Name != n.Name:
+			err = fmt.Errorf("cannot connect net %s to net %s", n.Name, mn.Name)
+		}
+		n.Global = n.Global || mn.Global
+		mn.Name = ""
+		mn.Global = false
+		switch {
+		case n.Class == nil:
+			n.Class = mn.Class
+		case mn.Class != nil && mn.Class != n.Class && err == nil:
+			err = fmt.Errorf("cannot connect a net of class %s to a net of class %s", n.Class.Name, mn.Class.Name)
+		}
+		mn.Class = nil
+		switch {
+		case n.DiffPair == nil:
+			n.DiffPair = mn.DiffPair
+		case mn.DiffPair == n.DiffPair && err == nil:
+			err = fmt.Errorf("cannot connect the two nets of differential pair %s to each other", n.DiffPair.Name)
+		case mn.DiffPair != nil && mn.DiffPair != n.DiffPair && err == nil:
+			err = fmt.Errorf("cannot connect a net of differential pair %s to a net of differential pair %s", n.DiffPair.Name, mn.DiffPair.Name)
+		}
+		mn.DiffPair = nil
 
 		for _, cb := range mn.onReplace {
 			// Notify about the new net
@@ -33,22 +108,48 @@ func (n *Net) Connect(e *Endpoint) {
 			// be called again if the net is successively replaced by another.
 			n.onReplace = append(n.onReplace, cb)
 		}
-		return
+		return err
 	}
 
 	n.Endpoints.Add(e)
 	e.Net = n
+	return nil
+}
+
+// SetClass attaches the given net class to the receiver, after checking
+// that its constraints are valid.
+//
+// An error is returned if the class is invalid or if the receiver already
+// has a different class, in which case the receiver is not changed.
+func (n *Net) SetClass(c *NetClass) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if n.Class != nil && n.Class != c {
+		return fmt.Errorf("net already has class %s", n.Class.Name)
+	}
+	n.Class = c
+	return nil
 }
 
 // SuggestedName attempts to suggest a name for the receiver based on the
 // names of its member endpoints.
 //
-// It is not always possible to produce a good result, and the result is
+// If the net has an explicit Name then that is always returned. Otherwise,
+// it is not always possible to produce a good result, and the result is
 // not guaranteed unique across a whole design. This is a "best effort" that
 // hopefully gives good results in some common cases.
 //
+// Ground names are preferred, followed by any endpoint names that look like
+// voltage rails, such as "+3V3" or "5V", and then by some generic supply and
+// signal names.
+//
 // If a name cannot be suggested at all, the result is an empty string.
 func (n *Net) SuggestedName() string {
+	if n.Name != "" {
+		return n.Name
+	}
+
 	if len(n.Endpoints) == 0 {
 		return ""
 	}
@@ -58,6 +159,26 @@ func (n *Net) SuggestedName() string {
 		nameOccurs[e.Name]++
 	}
 
+	for _, n := range groundNetNames {
+		if nameOccurs[n] > 0 {
+			return n
+		}
+	}
+
+	// We prefer the more specific rail name over the generic chip pin
+	// names below, so that in a multi-voltage circuit the name reflects
+	// which rail the net is. If there are several, we choose the
+	// lexically-first so that the result is deterministic.
+	var rail string
+	for name := range nameOccurs {
+		if IsRailName(name) && (rail == "" || name < rail) {
+			rail = name
+		}
+	}
+	if rail != "" {
+		return rail
+	}
+
 	for _, n := range priorityNetNames {
 		if nameOccurs[n] > 0 {
 			return n
@@ -67,6 +188,52 @@ func (n *Net) SuggestedName() string {
 	return ""
 }
 
+// IsRailName returns true if the given name looks like the name of a
+// voltage rail, such as "+3V3", "-12V", "1V8" or "+3.3V".
+//
+// A rail name consists of an optional sign, some digits, a "V", and
+// optionally some further digits, with either a decimal point before the
+// V or the further digits after it but not both.
+func IsRailName(name string) bool {
+	if len(name) > 0 && (name[0] == '+' || name[0] == '-') {
+		name = name[1:]
+	}
+
+	digits := func(s string) int {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i
+	}
+
+	i := digits(name)
+	if i == 0 {
+		return false
+	}
+	name = name[i:]
+
+	decimal := false
+	if len(name) > 0 && name[0] == '.' {
+		i = digits(name[1:])
+		if i == 0 {
+			return false
+		}
+		name = name[i+1:]
+		decimal = true
+	}
+
+	if len(name) == 0 || name[0] != 'V' {
+		return false
+	}
+	name = name[1:]
+
+	if len(name) == 0 {
+		return true
+	}
+	return !decimal && digits(name) == len(name)
+}
+
 // OnReplace arranges for the given callback to be called if the receiving
 // net is replaced due to being merged with another net.
 //
@@ -79,31 +246,13 @@ func (n *Net) OnReplace(cb func(new *Net)) {
 	n.onReplace = append(n.onReplace, cb)
 }
 
-var priorityNetNames = []string{
+var groundNetNames = []string{
 	"GND",
 	"AGND",
 	"PGND",
+}
 
-	// TODO: ideally we would recognize _all_ voltage-level-looking names
-	// here, but for now we'll just pick out some common ones.
-	// We list these before the generic IC supply connector names below so
-	// that in a multi-voltage circuit we'll prefer the more specific rail
-	// name over the generic chip pin name.
-	"+3V3",
-	"-3V3",
-	"+1V8",
-	"-1V8",
-	"+5V",
-	"-5V",
-	"+12V",
-	"-12V",
-	"+9V",
-	"-9V",
-	"+6V",
-	"-6V",
-	"+24V",
-	"-24V",
-
+var priorityNetNames = []string{
 	"V+",
 	"VCC",
 	"VDD",
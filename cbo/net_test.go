@@ -0,0 +1,224 @@
+package cbo
+
+import (
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+func TestNetSuggestedName(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Endpoints []string
+		Want      string
+	}{
+		{"", nil, ""},
+		{"", []string{"A", "B"}, ""},
+		{"", []string{"VCC", "A"}, "VCC"},
+		{"", []string{"VCC", "+3V3"}, "+3V3"},
+		{"", []string{"+5V", "3.3V", "VDD"}, "+5V"},
+		{"", []string{"+3V3", "GND"}, "GND"},
+		{"", []string{"VDD", "2V5"}, "2V5"},
+		{"LED_K", []string{"GND", "K"}, "LED_K"},
+		{"CLK", nil, "CLK"},
+	}
+
+	for _, test := range tests {
+		net := &Net{Name: test.Name, Endpoints: EndpointSet{}}
+		for _, name := range test.Endpoints {
+			net.Connect(&Endpoint{Name: name})
+		}
+		if got := net.SuggestedName(); got != test.Want {
+			t.Errorf("%q with %q suggests %q; want %q", test.Name, test.Endpoints, got, test.Want)
+		}
+	}
+}
+
+func TestIsRailName(t *testing.T) {
+	tests := map[string]bool{
+		"+3V3":  true,
+		"-3V3":  true,
+		"3V3":   true,
+		"+1V8":  true,
+		"+5V":   true,
+		"-12V":  true,
+		"24V":   true,
+		"+3.3V": true,
+		"0.9V":  true,
+		"":      false,
+		"+":     false,
+		"V":     false,
+		"+V":    false,
+		"VCC":   false,
+		"3V3A":  false,
+		"3.3V3": false,
+		"3.V":   false,
+		"+5v":   false,
+		"GND":   false,
+	}
+
+	for name, want := range tests {
+		if got := IsRailName(name); got != want {
+			t.Errorf("IsRailName(%q) = %v; want %v", name, got, want)
+		}
+	}
+}
+
+func TestNetConnectName(t *testing.T) {
+	a := &Net{Endpoints: EndpointSet{}}
+	b := &Net{Name: "+3V3", Global: true, Endpoints: EndpointSet{}}
+	ep := &Endpoint{Name: "VDD"}
+	b.Connect(ep)
+
+	a.Connect(ep)
+	if got, want := a.Name, "+3V3"; got != want {
+		t.Errorf("merged net has name %q; want %q", got, want)
+	}
+	if !a.Global {
+		t.Errorf("merged net is not global")
+	}
+	if b.Name != "" || b.Global {
+		t.Errorf("old net still has name %q (global %v)", b.Name, b.Global)
+	}
+}
+
+func TestFlatNetsGlobal(t *testing.T) {
+	sub := &Circuit{Name: "Sub"}
+	subInst := sub.NewInstance("sub", nil)
+	dev := &Device{
+		Name: "Dev",
+		Terminals: TerminalsDef{
+			All: map[string]Terminal{
+				"VDD": {Name: "VDD"},
+			},
+			Names: []string{"VDD"},
+		},
+	}
+	newDev := func() *DeviceInstance {
+		term := dev.Terminals.All["VDD"]
+		return &DeviceInstance{
+			Device: dev,
+			Terminals: map[string]*TerminalInstance{
+				"VDD": term.NewInstance(),
+			},
+		}
+	}
+	u1, u2, u3 := newDev(), newDev(), newDev()
+	subInst.Devices = map[string]*DeviceInstance{"U2": u2, "U3": u3}
+	root := &CircuitInstance{
+		Name:     "top",
+		Devices:  map[string]*DeviceInstance{"U1": u1},
+		Circuits: map[string]*CircuitInstance{"sub": subInst},
+	}
+
+	outer := &Net{Name: "+3V3", Global: true, Endpoints: EndpointSet{}}
+	outer.Connect(u1.Terminals["VDD"].Outside[0])
+	inner := &Net{Name: "+3V3", Global: true, Endpoints: EndpointSet{}}
+	inner.Connect(u2.Terminals["VDD"].Outside[0])
+	local := &Net{Name: "+3V3", Endpoints: EndpointSet{}}
+	local.Connect(u3.Terminals["VDD"].Outside[0])
+
+	flat := root.FlatNets()
+	if flat[outer] != flat[inner] {
+		t.Errorf("global nets of the same name are not joined")
+	}
+	if flat[outer] == flat[local] {
+		t.Errorf("non-global net is joined with global net of the same name")
+	}
+}
+
+func TestNetConnectNameConflict(t *testing.T) {
+	a := &Net{Name: "SDA", Endpoints: EndpointSet{}}
+	b := &Net{Name: "SCL", Endpoints: EndpointSet{}}
+	ep := &Endpoint{Name: "P1"}
+	b.Connect(ep)
+
+	if err := a.Connect(ep); err == nil {
+		t.Errorf("no error for connecting nets of different names")
+	}
+	if got, want := a.Name, "SDA"; got != want {
+		t.Errorf("merged net has name %q; want %q", got, want)
+	}
+
+	c := &Net{Name: "GND", Global: true, Endpoints: EndpointSet{}}
+	d := &Net{Name: "GND", Endpoints: EndpointSet{}}
+	ep = &Endpoint{Name: "P2"}
+	c.Connect(ep)
+	if err := d.Connect(ep); err != nil {
+		t.Errorf("unexpected error for nets of the same name: %s", err)
+	}
+	if !d.Global {
+		t.Errorf("merged net is not global")
+	}
+}
+
+func TestNetConnectSelf(t *testing.T) {
+	class := &NetClass{Name: "Power"}
+	n := &Net{Name: "+5V", Global: true, Class: class, Endpoints: EndpointSet{}}
+	ep := &Endpoint{Name: "VCC"}
+	n.Connect(ep)
+
+	if err := n.Connect(ep); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n.Name != "+5V" || !n.Global || n.Class != class {
+		t.Errorf("net lost its attributes: %q, global %v, class %v", n.Name, n.Global, n.Class)
+	}
+	if !n.Endpoints.Has(ep) {
+		t.Errorf("net lost its endpoint")
+	}
+}
+
+func TestNetConnectRoutingConflict(t *testing.T) {
+	power := &NetClass{Name: "Power"}
+	signal := &NetClass{Name: "Signal"}
+	a := &Net{Class: power, Endpoints: EndpointSet{}}
+	b := &Net{Class: signal, Endpoints: EndpointSet{}}
+	ep := &Endpoint{Name: "P1"}
+	b.Connect(ep)
+	if err := a.Connect(ep); err == nil {
+		t.Errorf("no error for connecting nets of different classes")
+	}
+	if a.Class != power {
+		t.Errorf("merged net has class %v; want %v", a.Class, power)
+	}
+
+	pos := &Net{Endpoints: EndpointSet{}}
+	neg := &Net{Endpoints: EndpointSet{}}
+	dp, err := NewDiffPair("USB", pos, neg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ep = &Endpoint{Name: "D-"}
+	neg.Connect(ep)
+	if err := pos.Connect(ep); err == nil {
+		t.Errorf("no error for shorting the nets of a differential pair")
+	}
+	if dp.Positive != pos || dp.Negative != pos {
+		t.Errorf("pair nets not updated after merge")
+	}
+}
+
+func TestNetSetClass(t *testing.T) {
+	width := units.MakeQuantityFloat(0.25, units.Millimeter)
+	voltage := units.MakeQuantityInt(5, units.Volt)
+	n := &Net{Endpoints: EndpointSet{}}
+
+	if err := n.SetClass(&NetClass{Name: "Bad", TraceWidth: &voltage}); err == nil {
+		t.Errorf("no error for invalid class")
+	}
+	if n.Class != nil {
+		t.Errorf("invalid class was attached")
+	}
+
+	class := &NetClass{Name: "Power", TraceWidth: &width}
+	if err := n.SetClass(class); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := n.SetClass(&NetClass{Name: "Other"}); err == nil {
+		t.Errorf("no error for replacing the class")
+	}
+	if n.Class != class {
+		t.Errorf("net has class %v; want %v", n.Class, class)
+	}
+}
@@ -0,0 +1,95 @@
+package cbo
+
+import (
+	"fmt"
+
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// NetClass is a named group of nets that share routing constraints, which
+// are passed on to layout tools.
+//
+// Each of the constraints is optional, with nil indicating that the layout
+// tool's default should be used.
+//
+// The language does not yet have syntax for declaring net classes or
+// differential pairs, because the bodies of circuits, where nets are
+// created, are not yet compiled. Until then, they must be attached with
+// Net.SetClass and NewDiffPair by whatever code builds the circuit objects.
+type NetClass struct {
+	Name string
+
+	TraceWidth *units.Quantity
+	Clearance  *units.Quantity
+	ViaSize    *units.Quantity
+}
+
+// Validate returns an error if any of the receiver's constraints is not a
+// length.
+func (c *NetClass) Validate() error {
+	for _, constraint := range []struct {
+		name string
+		q    *units.Quantity
+	}{
+		{"trace width", c.TraceWidth},
+		{"clearance", c.Clearance},
+		{"via size", c.ViaSize},
+	} {
+		if constraint.q != nil && !constraint.q.ConvertableTo(units.Meter) {
+			return fmt.Errorf("net class %s %s must be a length, not %s", c.Name, constraint.name, constraint.q)
+		}
+	}
+	return nil
+}
+
+// DiffPair is a pair of nets that carry a differential signal, and so must
+// be routed together as a coupled pair.
+type DiffPair struct {
+	Name string
+
+	// Positive and Negative are the two nets of the pair, such as D+ and D-
+	// of a USB interface. They are kept updated if either net is merged
+	// with another.
+	Positive *Net
+	Negative *Net
+
+	// Impedance, if non-nil, is the target differential impedance of the
+	// pair.
+	Impedance *units.Quantity
+}
+
+// NewDiffPair creates a differential pair of the given nets and records it
+// on both of them.
+//
+// An error is returned if the two nets are the same, if either net already
+// belongs to a differential pair, or if the given impedance is not a
+// resistance.
+func NewDiffPair(name string, pos, neg *Net, impedance *units.Quantity) (*DiffPair, error) {
+	if pos == neg {
+		return nil, fmt.Errorf("differential pair %s must have two distinct nets", name)
+	}
+	for _, net := range []*Net{pos, neg} {
+		if net.DiffPair != nil {
+			return nil, fmt.Errorf("net is already part of differential pair %s", net.DiffPair.Name)
+		}
+	}
+	if impedance != nil && !impedance.ConvertableTo(units.Ohm) {
+		return nil, fmt.Errorf("differential pair %s impedance must be a resistance, not %s", name, impedance)
+	}
+
+	dp := &DiffPair{
+		Name:      name,
+		Positive:  pos,
+		Negative:  neg,
+		Impedance: impedance,
+	}
+	pos.DiffPair = dp
+	neg.DiffPair = dp
+	pos.OnReplace(func(new *Net) {
+		dp.Positive = new
+	})
+	neg.OnReplace(func(new *Net) {
+		dp.Negative = new
+	})
+	return dp, nil
+}
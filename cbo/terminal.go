@@ -1,5 +1,10 @@
 package cbo
 
+import (
+	"fmt"
+	"sort"
+)
+
 type TerminalsDef struct {
 	All   map[string]Terminal
 	Names []string
@@ -24,6 +29,14 @@ type Terminal struct {
 
 	Role TerminalRole
 	ERC  ERCMode
+
+	// Voltage, if non-nil, describes the voltage characteristics of the
+	// terminal as seen from outside.
+	Voltage *VoltageSpec
+
+	// Waivers are any electrical rules waived for the nets the terminal is
+	// connected to on the outside.
+	Waivers []Waiver
 }
 
 func (t *Terminal) NewInstance() *TerminalInstance {
@@ -32,14 +45,18 @@ func (t *Terminal) NewInstance() *TerminalInstance {
 	inside := make([]*Endpoint, endpointCt)
 	for i := range outside {
 		outside[i] = &Endpoint{
-			Name: t.Name,
-			Net:  nil, // none yet; to be assigned when we start making connections
-			ERC:  t.ERC,
+			Name:    t.Name,
+			Net:     nil, // none yet; to be assigned when we start making connections
+			ERC:     t.ERC,
+			Role:    t.Role,
+			Voltage: t.Voltage,
+			Waivers: t.Waivers,
 		}
 		inside[i] = &Endpoint{
 			Name: t.Name,
 			Net:  nil,             // none yet; to be assigned when we start making connections
 			ERC:  t.ERC.Inverse(), // for input terminals, this produces an output of unknown type
+			Role: t.Role.Inverse(),
 		}
 	}
 	return &TerminalInstance{
@@ -120,3 +137,39 @@ func (r TerminalRole) Inverse() TerminalRole {
 		return NoRole
 	}
 }
+
+// terminalInstanceNames returns the names of the given terminal instances,
+// using the declaration order from the given definition where possible and
+// then falling back on lexicographical order for any not included there.
+func terminalInstanceNames(def TerminalsDef, insts map[string]*TerminalInstance) []string {
+	ret := make([]string, 0, len(insts))
+	seen := make(map[string]bool, len(insts))
+	for _, name := range def.Names {
+		if _, exists := insts[name]; exists && !seen[name] {
+			ret = append(ret, name)
+			seen[name] = true
+		}
+	}
+
+	var extra []string
+	for name := range insts {
+		if !seen[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+
+	return append(ret, extra...)
+}
+
+// EndpointName returns a name for the endpoint at the given index within
+// the receiver's Outside or Inside slices. For single-connection terminals
+// this is just the terminal name, while for bus terminals the name includes
+// the bus index in brackets.
+func (ti *TerminalInstance) EndpointName(idx int) string {
+	t := ti.Terminal
+	if t.LowerBound == 0 && t.UpperBound == 0 {
+		return t.Name
+	}
+	return fmt.Sprintf("%s[%d]", t.Name, t.LowerBound+idx)
+}
@@ -0,0 +1,51 @@
+package cbo
+
+import (
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// VoltageSpec describes the voltage characteristics of a terminal, for use
+// in checking that connected terminals belong to compatible voltage
+// domains.
+//
+// All of the fields are optional, with nil representing that the
+// characteristic is unspecified. Those that are set must be quantities
+// commensurable with volts, and are relative to the design's ground.
+type VoltageSpec struct {
+	// SupplyMin and SupplyMax describe a range of supply voltage. For a
+	// power input this is the range of voltage the terminal accepts, while
+	// for a power output it is the range of voltage the terminal produces.
+	// If only one is set, the range is a single nominal voltage.
+	SupplyMin *units.Quantity
+	SupplyMax *units.Quantity
+
+	// AbsMax is the absolute maximum voltage that may be applied to the
+	// terminal without risking damage.
+	AbsMax *units.Quantity
+
+	// VIH and VIL are the minimum voltage recognized as a logic high and
+	// the maximum voltage recognized as a logic low, for inputs.
+	VIH *units.Quantity
+	VIL *units.Quantity
+
+	// VOH and VOL are the minimum voltage produced for a logic high and
+	// the maximum voltage produced for a logic low, for outputs.
+	VOH *units.Quantity
+	VOL *units.Quantity
+}
+
+// SupplyRange returns the minimum and maximum of the receiver's supply
+// range, or nils if no supply voltage is specified.
+func (s *VoltageSpec) SupplyRange() (min, max *units.Quantity) {
+	if s == nil {
+		return nil, nil
+	}
+	min, max = s.SupplyMin, s.SupplyMax
+	if min == nil {
+		min = max
+	}
+	if max == nil {
+		max = min
+	}
+	return min, max
+}
@@ -0,0 +1,28 @@
+package cbo
+
+// Waiver records that a particular electrical rule is intentionally
+// violated, along with the designer's justification for doing so.
+//
+// Waivers can be attached to terminals, and thus to their endpoints, or to
+// nets. The rules checker will not report violations of the waived rule for
+// any net the waiver applies to, but will include the waiver and its
+// justification in reports so that it can be reviewed.
+//
+// The language does not yet have syntax for declaring waivers, because the
+// bodies of circuits, where nets and terminals are connected, are not yet
+// compiled. Until then, waivers must be attached by whatever code builds
+// the circuit objects.
+type Waiver struct {
+	// Rule is the stable identifier of the rule being waived, as defined
+	// by the rules checker.
+	Rule string
+
+	// Justification explains why the rule violation is acceptable. A waiver
+	// without a justification is not honored.
+	Justification string
+}
+
+// Justified returns true if the receiver has a non-empty justification.
+func (w Waiver) Justified() bool {
+	return w.Justification != ""
+}
@@ -0,0 +1,112 @@
+package cbo
+
+import (
+	"sort"
+	"strings"
+)
+
+// InstancePath is a sequence of instance names that describes how to reach
+// a particular circuit or device instance from some root circuit instance.
+//
+// The root instance itself has an empty path.
+type InstancePath []string
+
+// Child returns a new path that has the given name appended to the receiver.
+//
+// The receiver is not modified, so it is safe to call Child multiple times
+// on the same path to produce paths for sibling instances.
+func (p InstancePath) Child(name string) InstancePath {
+	ret := make(InstancePath, len(p), len(p)+1)
+	copy(ret, p)
+	return append(ret, name)
+}
+
+// String returns a dot-separated representation of the path, which is how
+// nested instances are addressed in Cirbo source code.
+func (p InstancePath) String() string {
+	return strings.Join(p, ".")
+}
+
+// WalkCircuits calls the given function for the receiver and then for each
+// circuit instance nested within it, depth-first.
+//
+// Sibling instances are visited in lexical order by name, so the visit order
+// is stable for a given hierarchy.
+func (ci *CircuitInstance) WalkCircuits(cb func(path InstancePath, inst *CircuitInstance)) {
+	ci.walkCircuits(nil, cb)
+}
+
+func (ci *CircuitInstance) walkCircuits(path InstancePath, cb func(path InstancePath, inst *CircuitInstance)) {
+	cb(path, ci)
+	for _, name := range ci.CircuitNames() {
+		ci.Circuits[name].walkCircuits(path.Child(name), cb)
+	}
+}
+
+// WalkDevices calls the given function for each device instance within the
+// receiver and within any circuit instances nested inside it, depth-first.
+//
+// The devices directly inside a particular circuit instance are visited
+// before the devices of its nested circuits, and sibling instances are
+// visited in lexical order by name.
+func (ci *CircuitInstance) WalkDevices(cb func(path InstancePath, inst *DeviceInstance)) {
+	ci.WalkCircuits(func(path InstancePath, inst *CircuitInstance) {
+		for _, name := range inst.DeviceNames() {
+			cb(path.Child(name), inst.Devices[name])
+		}
+	})
+}
+
+// CircuitNames returns the names of the circuit instances directly within
+// the receiver, sorted lexicographically.
+func (ci *CircuitInstance) CircuitNames() []string {
+	ret := make([]string, 0, len(ci.Circuits))
+	for k := range ci.Circuits {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// DeviceNames returns the names of the device instances directly within
+// the receiver, sorted lexicographically.
+func (ci *CircuitInstance) DeviceNames() []string {
+	ret := make([]string, 0, len(ci.Devices))
+	for k := range ci.Devices {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// EndpointPaths returns a map from each endpoint in the receiver's hierarchy
+// to a path that addresses it, for use in messages and reports.
+//
+// The path of a device terminal endpoint is the device instance path with
+// the endpoint name appended. Both the inside and outside endpoints of a
+// circuit instance's terminals use the circuit instance path with the
+// endpoint name appended, so they can be distinguished only by the nets they
+// belong to. The root circuit's own terminal endpoints have paths consisting
+// only of the endpoint name.
+func (ci *CircuitInstance) EndpointPaths() map[*Endpoint]InstancePath {
+	ret := map[*Endpoint]InstancePath{}
+	ci.WalkCircuits(func(path InstancePath, inst *CircuitInstance) {
+		for _, term := range inst.Terminals {
+			for i, ep := range term.Inside {
+				ret[ep] = path.Child(term.EndpointName(i))
+			}
+			for i, ep := range term.Outside {
+				ret[ep] = path.Child(term.EndpointName(i))
+			}
+		}
+		for name, dev := range inst.Devices {
+			devPath := path.Child(name)
+			for _, term := range dev.Terminals {
+				for i, ep := range term.Outside {
+					ret[ep] = devPath.Child(term.EndpointName(i))
+				}
+			}
+		}
+	})
+	return ret
+}
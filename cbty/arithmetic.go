@@ -4,6 +4,7 @@ package cbty
 // can do arithmetic.
 type typeWithArithmetic interface {
 	CanSum(other Type) bool
+	CanSubtract(other Type) bool
 	CanProduct(other Type) bool
 
 	Add(a, b Value) Value
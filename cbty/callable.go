@@ -2,6 +2,7 @@ package cbty
 
 import (
 	"github.com/cirbo-lang/cirbo/source"
+	"github.com/cirbo-lang/cirbo/units"
 )
 
 type typeCallable interface {
@@ -62,6 +63,13 @@ type CallArgs struct {
 	Context interface{}
 }
 
+// UnitResolver is implemented by the Context of CallArgs when units declared
+// in packages can be found by name, for functions that accept the name of a
+// unit as a string.
+type UnitResolver interface {
+	UnitByName(name string) *units.Unit
+}
+
 // Same returns true if the receiver and the other given signature are
 // equivalent.
 func (s *CallSignature) Same(o *CallSignature) bool {
@@ -0,0 +1,174 @@
+package cbty
+
+import (
+	"fmt"
+
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// complexImpl is the typeImpl for complex quantities, such as impedances.
+type complexImpl struct {
+	isType
+	dim units.Dimensionality
+}
+
+// ComplexQuantity returns the type of complex quantities of the given
+// dimensionality, such as impedances.
+//
+// Complex quantities can be mixed in arithmetic with the (real) quantity
+// types, which are promoted to complex quantities with a zero imaginary
+// part. Complex quantities do not have tolerances, so callers must not mix
+// toleranced quantities into complex arithmetic.
+func ComplexQuantity(dim units.Dimensionality) Type {
+	return Type{complexImpl{dim: dim}}
+}
+
+// Complex is the type of dimensionless complex numbers.
+var Complex Type = ComplexQuantity(units.Dimensionality{})
+
+// Impedance is the type of complex resistances, such as the impedance of
+// a capacitor at a particular frequency.
+var Impedance Type = ComplexQuantity(Resistance.NumberDimensionality())
+
+// Admittance is the type of complex conductances, which are the reciprocals
+// of impedances.
+var Admittance Type = ComplexQuantity(Conductance.NumberDimensionality())
+
+var complexTypeNames = map[units.Dimensionality]string{
+	units.Dimensionality{}: "Complex",
+}
+
+func init() {
+	complexTypeNames[Impedance.ComplexDimensionality()] = "Impedance"
+	complexTypeNames[Admittance.ComplexDimensionality()] = "Admittance"
+}
+
+func ComplexQuantityVal(c units.ComplexQuantity) Value {
+	return Value{
+		v:  c,
+		ty: ComplexQuantity(c.Unit().Dimensionality()),
+	}
+}
+
+// AsComplexQuantity returns the units.ComplexQuantity value of the receiver
+// if it is known and of a complex quantity type or an exact, non-level number
+// type, or panics otherwise.
+//
+// A number is promoted to a complex quantity with a zero imaginary part.
+func (v Value) AsComplexQuantity() units.ComplexQuantity {
+	if v.IsUnknown() {
+		panic("AsComplexQuantity on unknown value")
+	}
+	if v.IsToleranced() {
+		panic("AsComplexQuantity on toleranced value")
+	}
+	switch {
+	case v.Type().IsComplex():
+		return v.v.(units.ComplexQuantity)
+	case v.Type().IsNumber() && !v.Type().IsLevel():
+		return units.MakeReal(v.AsQuantity())
+	default:
+		panic("AsComplexQuantity on non-number value")
+	}
+}
+
+// convertComplexUnit is the implementation of ConvertUnit for complex
+// quantity values.
+func (v Value) convertComplexUnit(unit *units.Unit) Value {
+	if unit.Logarithmic() || unit.OffsetTemperature() || !v.ty.Same(ComplexQuantity(unit.Dimensionality())) {
+		panic(fmt.Sprintf("ConvertUnit on %s value with unit %s", v.ty.Name(), unit))
+	}
+	if v.IsUnknown() {
+		return v
+	}
+
+	return ComplexQuantityVal(v.v.(units.ComplexQuantity).Convert(unit))
+}
+
+func (i complexImpl) Name() string {
+	if name := complexTypeNames[i.dim]; name != "" {
+		return name
+	}
+	if name := numberTypeNames[i.dim]; name != "" {
+		return fmt.Sprintf("Complex(%s)", name)
+	}
+	return fmt.Sprintf("Complex(%s)", i.dim.String())
+}
+
+func (i complexImpl) GoString() string {
+	if name := complexTypeNames[i.dim]; name != "" {
+		return "cty." + name
+	}
+	return fmt.Sprintf("cty.ComplexQuantity(%#v)", i.dim)
+}
+
+func (i complexImpl) Equal(a, b Value) Value {
+	av := a.v.(units.ComplexQuantity)
+	bv := b.v.(units.ComplexQuantity)
+	return BoolVal(av.Equal(bv))
+}
+
+func (i complexImpl) CanSum(other Type) bool {
+	dim, ok := complexOperandDim(other)
+	return ok && i.dim == dim
+}
+
+func (i complexImpl) CanSubtract(other Type) bool {
+	return i.CanSum(other)
+}
+
+func (i complexImpl) CanProduct(other Type) bool {
+	_, ok := complexOperandDim(other)
+	return ok
+}
+
+func (i complexImpl) Add(a, b Value) Value {
+	if a.IsUnknown() || b.IsUnknown() {
+		return UnknownVal(ComplexQuantity(i.dim))
+	}
+
+	return ComplexQuantityVal(a.AsComplexQuantity().Add(b.AsComplexQuantity()))
+}
+
+func (i complexImpl) Subtract(a, b Value) Value {
+	if a.IsUnknown() || b.IsUnknown() {
+		return UnknownVal(ComplexQuantity(i.dim))
+	}
+
+	return ComplexQuantityVal(a.AsComplexQuantity().Subtract(b.AsComplexQuantity()))
+}
+
+func (i complexImpl) Multiply(a, b Value) Value {
+	if a.IsUnknown() || b.IsUnknown() {
+		aDim, _ := complexOperandDim(a.ty)
+		bDim, _ := complexOperandDim(b.ty)
+		return UnknownVal(ComplexQuantity(aDim.Multiply(bDim)))
+	}
+
+	return ComplexQuantityVal(a.AsComplexQuantity().Multiply(b.AsComplexQuantity()))
+}
+
+func (i complexImpl) Divide(a, b Value) Value {
+	if a.IsUnknown() || b.IsUnknown() {
+		aDim, _ := complexOperandDim(a.ty)
+		bDim, _ := complexOperandDim(b.ty)
+		return UnknownVal(ComplexQuantity(aDim.Multiply(bDim.Reciprocal())))
+	}
+
+	return ComplexQuantityVal(a.AsComplexQuantity().Divide(b.AsComplexQuantity()))
+}
+
+// complexOperandDim returns the dimensionality of the given type if it can
+// be an operand of complex arithmetic, meaning that it is either a complex
+// quantity type or a non-level number type, or false as its second result
+// otherwise.
+func complexOperandDim(ty Type) (units.Dimensionality, bool) {
+	switch impl := ty.impl.(type) {
+	case complexImpl:
+		return impl.dim, true
+	case numberImpl:
+		return impl.dim, !impl.level
+	default:
+		return units.Dimensionality{}, false
+	}
+}
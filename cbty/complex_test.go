@@ -0,0 +1,91 @@
+package cbty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+func TestComplexTypeName(t *testing.T) {
+	tests := []struct {
+		Type Type
+		Want string
+	}{
+		{Complex, "Complex"},
+		{Impedance, "Impedance"},
+		{Admittance, "Admittance"},
+		{ComplexQuantity(Voltage.NumberDimensionality()), "Complex(Voltage)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Want, func(t *testing.T) {
+			if got := test.Type.Name(); got != test.Want {
+				t.Errorf("wrong name %q; want %q", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestComplexArithmetic(t *testing.T) {
+	z := testComplex("50", "30", "ohm")
+
+	tests := []struct {
+		Name string
+		Got  Value
+		Want Value
+	}{
+		{"add", z.Add(z), testComplex("100", "60", "ohm")},
+		{"add real", testNumber("1", "kohm").Add(z), testComplex("1050", "30", "ohm")},
+		{"subtract real", z.Subtract(testNumber("50", "ohm")), testComplex("0", "30", "ohm")},
+		{"multiply", testNumber("2", "A").Multiply(z), testComplex("100", "60", "V")},
+		{"divide", testNumber("1", "").Divide(testComplex("0", "2", "")), testComplex("0", "-0.5", "")},
+		{"convert", z.ConvertUnit(units.ByName("kohm")), testComplex("0.05", "0.03", "kohm")},
+		{"unknown sum", UnknownVal(Resistance).Add(z), UnknownVal(Impedance)},
+		{"unknown product", UnknownVal(Current).Multiply(z), UnknownVal(ComplexQuantity(Voltage.NumberDimensionality()))},
+		{"unknown quotient", testNumber("1", "").Divide(UnknownVal(Impedance)), UnknownVal(Admittance)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if !test.Got.Same(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", test.Got, test.Want)
+			}
+		})
+	}
+}
+
+func TestComplexOperandTypes(t *testing.T) {
+	tests := []struct {
+		A, B       Type
+		CanSum     bool
+		CanProduct bool
+	}{
+		{Impedance, Impedance, true, true},
+		{Impedance, Resistance, true, true},
+		{Resistance, Impedance, true, true},
+		{Impedance, Capacitance, false, true},
+		{Frequency, Impedance, false, true},
+		{Impedance, Gain, false, false},
+		{Gain, Impedance, false, false},
+		{Impedance, String, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v and %#v", test.A, test.B), func(t *testing.T) {
+			if got := test.A.CanSum(test.B); got != test.CanSum {
+				t.Errorf("wrong CanSum %#v; want %#v", got, test.CanSum)
+			}
+			if got := test.A.CanProduct(test.B); got != test.CanProduct {
+				t.Errorf("wrong CanProduct %#v; want %#v", got, test.CanProduct)
+			}
+		})
+	}
+}
+
+func testComplex(re, im, u string) Value {
+	return ComplexQuantityVal(units.MakeComplexQuantity(
+		testNumber(re, u).AsQuantity(),
+		testNumber(im, u).AsQuantity(),
+	))
+}
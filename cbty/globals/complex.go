@@ -0,0 +1,246 @@
+package globals
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cirbo-lang/cirbo/cbty"
+	"github.com/cirbo-lang/cirbo/source"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// Abs, Phase, Re, Im and Conj are functions that each take a single complex
+// quantity and return, respectively, its magnitude, its phase angle, its real
+// part, its imaginary part, and its complex conjugate. For example:
+//
+//     abs(30ohm + 40j ohm)
+//
+// returns 50 ohm. An exact number may also be given, which is treated as a
+// complex quantity with a zero imaginary part.
+var Abs, Phase, Re, Im, Conj cbty.Value
+
+// Polar is a function that returns the complex quantity with a given
+// magnitude and phase angle, such as a voltage phasor:
+//
+//     polar(5V, 30deg)
+var Polar cbty.Value
+
+// ZC is a function that returns the impedance of a capacitor with a given
+// capacitance at a given frequency, which is 1/(j2πfC). For example:
+//
+//     Z_C(1uF, 1kHz)
+//
+// returns approximately -159.15j ohm.
+var ZC cbty.Value
+
+// ZL is a function that returns the impedance of an inductor with a given
+// inductance at a given frequency, which is j2πfL.
+var ZL cbty.Value
+
+// Complex quantities cannot have tolerances, so each of these functions
+// rejects toleranced arguments rather than silently discarding their ranges.
+const tolerancedComplexArg = "The %s must be exact, because complex quantities cannot have tolerances."
+
+func init() {
+	Abs = complexFunction(cbty.PlaceholderVal.Type(), func(c units.ComplexQuantity) cbty.Value {
+		return cbty.QuantityVal(c.Abs())
+	})
+	Phase = complexFunction(cbty.Angle, func(c units.ComplexQuantity) cbty.Value {
+		return cbty.QuantityVal(c.Phase())
+	})
+	Re = complexFunction(cbty.PlaceholderVal.Type(), func(c units.ComplexQuantity) cbty.Value {
+		return cbty.QuantityVal(c.Real())
+	})
+	Im = complexFunction(cbty.PlaceholderVal.Type(), func(c units.ComplexQuantity) cbty.Value {
+		return cbty.QuantityVal(c.Imag())
+	})
+	Conj = complexFunction(cbty.PlaceholderVal.Type(), func(c units.ComplexQuantity) cbty.Value {
+		return cbty.ComplexQuantityVal(c.Conjugate())
+	})
+
+	Polar = cbty.FunctionVal(cbty.FunctionImpl{
+		Signature: &cbty.CallSignature{
+			Parameters: map[string]cbty.CallParameter{},
+
+			// The magnitude may be of any number type and so the result
+			// type depends on it, which our signatures can't express, so
+			// we check the arguments ourselves.
+			Result:                    cbty.PlaceholderVal.Type(),
+			AcceptsVariadicPositional: true,
+		},
+		Callback: func(args cbty.CallArgs) (cbty.Value, source.Diags) {
+			if len(args.PosVariadic) != 2 {
+				return cbty.PlaceholderVal, source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incorrect number of arguments",
+						Detail:  "This function requires two positional arguments: the magnitude and the phase angle.",
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+			mag, phase := args.PosVariadic[0], args.PosVariadic[1]
+
+			if !mag.Type().IsNumber() || mag.Type().IsLevel() {
+				return cbty.PlaceholderVal, source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incorrect argument type",
+						Detail:  fmt.Sprintf("The magnitude must be a quantity, not %s.", mag.Type().Name()),
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+			if !phase.Type().Same(cbty.Angle) {
+				return cbty.PlaceholderVal, source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incorrect argument type",
+						Detail:  fmt.Sprintf("The phase must be of type Angle, not %s.", phase.Type().Name()),
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+
+			for _, arg := range []struct {
+				name string
+				val  cbty.Value
+			}{{"magnitude", mag}, {"phase", phase}} {
+				if arg.val.IsToleranced() {
+					return cbty.PlaceholderVal, source.Diags{
+						{
+							Level:   source.Error,
+							Summary: "Invalid argument",
+							Detail:  fmt.Sprintf(tolerancedComplexArg, arg.name),
+							Ranges:  args.CallRange.List(),
+						},
+					}
+				}
+			}
+
+			return cbty.ComplexQuantityVal(units.MakePolar(mag.AsQuantity(), phase.AsQuantity())), nil
+		},
+	})
+
+	ZC = reactanceFunction("C", cbty.Capacitance, func(x, w units.Quantity) (units.ComplexQuantity, string) {
+		wx := w.Multiply(x)
+		if wx.Value().Sign() == 0 {
+			return units.ComplexQuantity{}, "The impedance of a capacitor is infinite when the capacitance or the frequency is zero."
+		}
+		one := units.MakeReal(units.MakeDimensionlessInt(1))
+		return one.Divide(units.MakeImaginary(wx)), ""
+	})
+	ZL = reactanceFunction("L", cbty.Inductance, func(x, w units.Quantity) (units.ComplexQuantity, string) {
+		return units.MakeImaginary(w.Multiply(x)), ""
+	})
+}
+
+// complexFunction returns a function that takes a single complex quantity or
+// number argument and returns the result of the given callback.
+//
+// The result type given is the result type of the function signature, which
+// is the type of the result when the argument is unknown.
+func complexFunction(result cbty.Type, cb func(c units.ComplexQuantity) cbty.Value) cbty.Value {
+	return cbty.FunctionVal(cbty.FunctionImpl{
+		Signature: &cbty.CallSignature{
+			Parameters: map[string]cbty.CallParameter{},
+			Result:     result,
+
+			// The argument may be of any complex or number type, which our
+			// signatures can't express, so we check the arguments ourselves.
+			AcceptsVariadicPositional: true,
+		},
+		Callback: func(args cbty.CallArgs) (cbty.Value, source.Diags) {
+			if len(args.PosVariadic) != 1 {
+				return cbty.UnknownVal(result), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incorrect number of arguments",
+						Detail:  "This function requires one positional argument: a complex quantity.",
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+			val := args.PosVariadic[0]
+
+			ty := val.Type()
+			if !ty.IsComplex() && (!ty.IsNumber() || ty.IsLevel()) {
+				return cbty.UnknownVal(result), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incorrect argument type",
+						Detail:  fmt.Sprintf("The argument must be a complex quantity, not %s.", ty.Name()),
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+			if val.IsToleranced() {
+				return cbty.UnknownVal(result), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Invalid argument",
+						Detail:  fmt.Sprintf(tolerancedComplexArg, "argument"),
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+
+			return cb(val.AsComplexQuantity()), nil
+		},
+	})
+}
+
+// reactanceFunction returns a function that takes a component value of the
+// given type, such as a capacitance, and a frequency, and returns the
+// impedance produced by the given callback, which receives the component
+// value and the angular frequency. If the impedance is undefined, the
+// callback instead returns the detail message for an error diagnostic.
+func reactanceFunction(name string, ty cbty.Type, cb func(x, w units.Quantity) (units.ComplexQuantity, string)) cbty.Value {
+	return cbty.FunctionVal(cbty.FunctionImpl{
+		Signature: &cbty.CallSignature{
+			Parameters: map[string]cbty.CallParameter{
+				name: {
+					Type:     ty,
+					Required: true,
+				},
+				"f": {
+					Type:     cbty.Frequency,
+					Required: true,
+				},
+			},
+			Positional: []string{name, "f"},
+			Result:     cbty.Impedance,
+		},
+		Callback: func(args cbty.CallArgs) (cbty.Value, source.Diags) {
+			for _, argName := range []string{name, "f"} {
+				if args.Explicit[argName].IsToleranced() {
+					return cbty.UnknownVal(cbty.Impedance), source.Diags{
+						{
+							Level:   source.Error,
+							Summary: "Invalid argument",
+							Detail:  fmt.Sprintf(tolerancedComplexArg, argName),
+							Ranges:  args.CallRange.List(),
+						},
+					}
+				}
+			}
+
+			x := args.Explicit[name].AsQuantity()
+			f := args.Explicit["f"].AsQuantity()
+			w := f.Multiply(units.MakeDimensionlessFloat(2 * math.Pi))
+
+			z, problem := cb(x, w)
+			if problem != "" {
+				return cbty.UnknownVal(cbty.Impedance), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Invalid argument",
+						Detail:  problem,
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+			return cbty.ComplexQuantityVal(z.Convert(units.ByName("ohm"))), nil
+		},
+	})
+}
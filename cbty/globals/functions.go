@@ -0,0 +1,103 @@
+package globals
+
+import (
+	"fmt"
+
+	"github.com/cirbo-lang/cirbo/cbty"
+	"github.com/cirbo-lang/cirbo/source"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// ValueIn is a function that returns the magnitude of a number when expressed
+// in a given unit, as a dimensionless number. For example:
+//
+//     value_in(25.4mm, "mil")
+//
+// returns 1000. Unlike a conversion expression, the unit is given as a string
+// so that it can be chosen dynamically, such as from a parameter.
+//
+// As with a conversion expression, a complex quantity may also be given, in
+// which case the result is a dimensionless complex number.
+var ValueIn cbty.Value
+
+func init() {
+	ValueIn = cbty.FunctionVal(cbty.FunctionImpl{
+		Signature: &cbty.CallSignature{
+			Parameters: map[string]cbty.CallParameter{},
+			Result:     cbty.Number,
+
+			// The first argument may be of any number type, which our
+			// signatures can't express, so we check the arguments ourselves.
+			AcceptsVariadicPositional: true,
+		},
+		Callback: func(args cbty.CallArgs) (cbty.Value, source.Diags) {
+			if len(args.PosVariadic) != 2 {
+				return cbty.UnknownVal(cbty.Number), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incorrect number of arguments",
+						Detail:  "This function requires two positional arguments: the value to convert and the name of a unit.",
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+			val, unitName := args.PosVariadic[0], args.PosVariadic[1]
+
+			if !unitName.Type().Same(cbty.String) {
+				return cbty.UnknownVal(cbty.Number), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incorrect argument type",
+						Detail:  fmt.Sprintf("The unit name must be of type String, not %s.", unitName.Type().Name()),
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+			if unitName.IsUnknown() {
+				return cbty.UnknownVal(cbty.Number), nil
+			}
+			unit := units.ByName(unitName.AsString())
+			if resolver, ok := args.Context.(cbty.UnitResolver); ok && unit == nil {
+				// The unit might instead be declared in a package.
+				unit = resolver.UnitByName(unitName.AsString())
+			}
+			if unit == nil {
+				return cbty.UnknownVal(cbty.Number), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Invalid unit",
+						Detail:  fmt.Sprintf("The name %q is not a known quantity unit.", unitName.AsString()),
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+
+			if !val.Type().IsNumber() && !val.Type().IsComplex() {
+				return cbty.UnknownVal(cbty.Number), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incorrect argument type",
+						Detail:  fmt.Sprintf("The value to convert must be a number, not %s.", val.Type().Name()),
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+			wantTy := cbty.UnitType(unit)
+			if val.Type().IsComplex() && !unit.Logarithmic() && !unit.OffsetTemperature() {
+				wantTy = cbty.ComplexQuantity(unit.Dimensionality())
+			}
+			if !val.Type().Same(wantTy) {
+				return cbty.UnknownVal(cbty.Number), source.Diags{
+					{
+						Level:   source.Error,
+						Summary: "Incompatible unit",
+						Detail:  fmt.Sprintf("A %s value cannot be expressed in %s, which is a unit of %s.", val.Type().Name(), unit, wantTy.Name()),
+						Ranges:  args.CallRange.List(),
+					},
+				}
+			}
+
+			return val.ValueIn(unit), nil
+		},
+	})
+}
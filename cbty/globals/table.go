@@ -11,18 +11,22 @@ import (
 // so that they can be conveniently accessed by integration code.
 func Table() map[string]cbty.Value {
 	return map[string]cbty.Value{
+		"Admittance":        Admittance,
 		"Angle":             Angle,
 		"AngularSpeed":      AngularSpeed,
 		"Area":              Area,
 		"Bool":              Bool,
 		"Capacitance":       Capacitance,
 		"Charge":            Charge,
+		"Complex":           Complex,
 		"Conductance":       Conductance,
 		"Conductivity":      Conductivity,
 		"Current":           Current,
 		"Force":             Force,
 		"Frequency":         Frequency,
+		"Gain":              Gain,
 		"Illuminance":       Illuminance,
+		"Impedance":         Impedance,
 		"Inductance":        Inductance,
 		"Length":            Length,
 		"LuminousIntensity": LuminousIntensity,
@@ -31,12 +35,28 @@ func Table() map[string]cbty.Value {
 		"Number":            Number,
 		"Object":            Object,
 		"Power":             Power,
+		"PowerLevel":        PowerLevel,
 		"Resistance":        Resistance,
 		"Resistivity":       Resistivity,
 		"Speed":             Speed,
 		"String":            String,
+		"Temperature":       Temperature,
+		"ThermalResistance": ThermalResistance,
 		"Time":              Time,
 		"Type":              Type,
+		"Unit":              Unit,
 		"Voltage":           Voltage,
+		"VoltageLevel":      VoltageLevel,
+
+		"value_in": ValueIn,
+
+		"Z_C":   ZC,
+		"Z_L":   ZL,
+		"abs":   Abs,
+		"conj":  Conj,
+		"im":    Im,
+		"phase": Phase,
+		"polar": Polar,
+		"re":    Re,
 	}
 }
@@ -12,6 +12,13 @@ import (
 var String = cbty.TypeTypeVal(cbty.String)
 var Bool = cbty.TypeTypeVal(cbty.Bool)
 var Type = cbty.TypeTypeVal(cbty.TypeType)
+var Gain = cbty.TypeTypeVal(cbty.Gain)
+var PowerLevel = cbty.TypeTypeVal(cbty.PowerLevel)
+var VoltageLevel = cbty.TypeTypeVal(cbty.VoltageLevel)
+var Complex = cbty.TypeTypeVal(cbty.Complex)
+var Impedance = cbty.TypeTypeVal(cbty.Impedance)
+var Admittance = cbty.TypeTypeVal(cbty.Admittance)
+var Unit = cbty.TypeTypeVal(cbty.Unit)
 var Object cbty.Value
 
 func init() {
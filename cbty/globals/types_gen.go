@@ -29,5 +29,7 @@ var Power cbty.Value = cbty.TypeTypeVal(cbty.Power)
 var Resistance cbty.Value = cbty.TypeTypeVal(cbty.Resistance)
 var Resistivity cbty.Value = cbty.TypeTypeVal(cbty.Resistivity)
 var Speed cbty.Value = cbty.TypeTypeVal(cbty.Speed)
+var Temperature cbty.Value = cbty.TypeTypeVal(cbty.Temperature)
+var ThermalResistance cbty.Value = cbty.TypeTypeVal(cbty.ThermalResistance)
 var Time cbty.Value = cbty.TypeTypeVal(cbty.Time)
 var Voltage cbty.Value = cbty.TypeTypeVal(cbty.Voltage)
@@ -11,6 +11,13 @@ import (
 type numberImpl struct {
 	isType
 	dim units.Dimensionality
+
+	// level is true for logarithmic levels, such as power in dBm, in which
+	// case dim is the dimensionality of the reference quantity.
+	level bool
+
+	// name, if set, overrides the name of the type. See NamedQuantity.
+	name string
 }
 
 // Zero is a value of type Number that represents zero
@@ -23,13 +30,109 @@ func Quantity(dim units.Dimensionality) Type {
 	return Type{numberImpl{dim: dim}}
 }
 
+// UnitType returns the type of the number values that can be expressed in
+// the given unit, which is a level type if the unit is logarithmic.
+func UnitType(unit *units.Unit) Type {
+	if unit.Logarithmic() {
+		return Level(unit.Dimensionality())
+	}
+	return Quantity(unit.Dimensionality())
+}
+
+// NamedQuantity returns the quantity type of the given dimensionality, but
+// with the given name for display. This allows Cirbo packages to declare
+// names for dimensionalities that do not have a built-in name.
+//
+// The name is for display only, so the result is the same type as would be
+// returned by Quantity for the same dimensionality.
+func NamedQuantity(name string, dim units.Dimensionality) Type {
+	return Type{numberImpl{dim: dim, name: name}}
+}
+
 func QuantityVal(q units.Quantity) Value {
 	return Value{
 		v:  q,
-		ty: Quantity(q.Unit().Dimensionality()),
+		ty: UnitType(q.Unit()),
 	}
 }
 
+// TolerancedQuantityVal returns a number value whose value is only known to
+// lie within the range of the given quantity, such as the resistance of a
+// resistor with a 1% tolerance.
+//
+// Toleranced values have the same types as exact values of the same
+// dimensionality, and they can be mixed freely with exact values in
+// arithmetic, which then uses interval arithmetic to produce a toleranced
+// result. If the given quantity has no tolerance, the result is an exact
+// value as would be returned by QuantityVal.
+func TolerancedQuantityVal(q units.TolerancedQuantity) Value {
+	if q.Exact() {
+		return QuantityVal(q.Nom())
+	}
+	return Value{
+		v:  q,
+		ty: QuantityVal(q.Nom()).ty,
+	}
+}
+
+// ConvertUnit returns a number value equivalent to the receiver but expressed
+// in the given unit, preserving any tolerance. An unknown receiver produces
+// an unknown result of the same type.
+//
+// A complex quantity can also be converted to any non-logarithmic unit of
+// the same dimensionality.
+//
+// Will panic if the receiver is not of the type returned by UnitType for the
+// given unit, meaning that its values are not commensurable with the unit.
+func (v Value) ConvertUnit(unit *units.Unit) Value {
+	if v.ty.IsComplex() {
+		return v.convertComplexUnit(unit)
+	}
+	if !v.ty.Same(UnitType(unit)) {
+		panic(fmt.Sprintf("ConvertUnit on %s value with unit %s", v.ty.Name(), unit))
+	}
+	if v.IsUnknown() {
+		return v
+	}
+
+	if tq, tol := v.v.(units.TolerancedQuantity); tol {
+		return TolerancedQuantityVal(tq.Convert(unit))
+	}
+	return QuantityVal(v.v.(units.Quantity).Convert(unit))
+}
+
+// ValueIn returns a value of type Number whose value is the magnitude of the
+// receiver when expressed in the given unit. For example, 1 mm in mil is
+// the dimensionless number 39.37..., which is useful when a plain number is
+// required, such as when producing data for an external tool.
+//
+// For a complex quantity, the result is instead of type Complex.
+//
+// Will panic under the same conditions as ConvertUnit.
+func (v Value) ValueIn(unit *units.Unit) Value {
+	conv := v.ConvertUnit(unit)
+	if v.ty.IsComplex() {
+		if conv.IsUnknown() {
+			return UnknownVal(Complex)
+		}
+		c := conv.AsComplexQuantity()
+		return ComplexQuantityVal(units.MakeComplexQuantity(
+			units.MakeDimensionless(c.Real().Value()),
+			units.MakeDimensionless(c.Imag().Value()),
+		))
+	}
+	if conv.IsUnknown() {
+		return UnknownVal(Number)
+	}
+
+	tq := conv.AsTolerancedQuantity()
+	return TolerancedQuantityVal(units.MakeTolerancedQuantity(
+		units.MakeDimensionless(tq.Min().Value()),
+		units.MakeDimensionless(tq.Nom().Value()),
+		units.MakeDimensionless(tq.Max().Value()),
+	))
+}
+
 func NumberValInt(v int64) Value {
 	return QuantityVal(
 		units.MakeDimensionless(
@@ -47,6 +150,16 @@ func NumberValFloat(v float64) Value {
 }
 
 func (i numberImpl) Name() string {
+	if i.name != "" {
+		return i.name
+	}
+	if i.level {
+		if name := levelTypeNames[i.dim]; name != "" {
+			return name
+		}
+		return fmt.Sprintf("Level(%s)", i.dim.String())
+	}
+
 	name := numberTypeNames[i.dim]
 	if name != "" {
 		return name
@@ -58,6 +171,16 @@ func (i numberImpl) Name() string {
 }
 
 func (i numberImpl) GoString() string {
+	if i.name != "" {
+		return fmt.Sprintf("cty.NamedQuantity(%q, %#v)", i.name, i.dim)
+	}
+	if i.level {
+		if name := levelTypeNames[i.dim]; name != "" {
+			return "cty." + name
+		}
+		return fmt.Sprintf("cty.Level(%#v)", i.dim)
+	}
+
 	name := numberTypeNames[i.dim]
 	if name != "" {
 		return "cty." + name
@@ -66,63 +189,148 @@ func (i numberImpl) GoString() string {
 	return fmt.Sprintf("cty.Quantity(%#v)", i.dim)
 }
 
+func (i numberImpl) Same(o Type) bool {
+	oi, isNumber := o.impl.(numberImpl)
+	return isNumber && i.dim == oi.dim && i.level == oi.level
+}
+
 func (i numberImpl) Equal(a, b Value) Value {
+	if at, bt, tol := tolerancedOperands(a, b); tol {
+		return BoolVal(at.Equal(bt))
+	}
+
 	av := a.v.(units.Quantity)
 	bv := b.v.(units.Quantity)
 	return BoolVal(av.Equal(bv))
 }
 
 func (i numberImpl) CanSum(other Type) bool {
+	if otherComplex, isComplex := other.impl.(complexImpl); isComplex {
+		return !i.level && i.dim == otherComplex.dim
+	}
 	otherNum, isNumber := other.impl.(numberImpl)
-	if !isNumber {
+	if !isNumber || i.level != otherNum.level {
 		return false
 	}
+	if i.level {
+		// A gain can be added to a level, but two levels cannot be added.
+		return i.isGain() || otherNum.isGain()
+	}
+	return i.dim == otherNum.dim
+}
+
+func (i numberImpl) CanSubtract(other Type) bool {
+	if otherComplex, isComplex := other.impl.(complexImpl); isComplex {
+		return !i.level && i.dim == otherComplex.dim
+	}
+	otherNum, isNumber := other.impl.(numberImpl)
+	if !isNumber || i.level != otherNum.level {
+		return false
+	}
+	if i.level {
+		// A gain can be subtracted from a level, and the difference between
+		// two levels of the same dimensionality is a gain.
+		return otherNum.isGain() || i.dim == otherNum.dim
+	}
 	return i.dim == otherNum.dim
 }
 
 func (i numberImpl) CanProduct(other Type) bool {
-	_, isNumber := other.impl.(numberImpl)
-	return isNumber
+	if _, isComplex := other.impl.(complexImpl); isComplex {
+		return !i.level
+	}
+	otherNum, isNumber := other.impl.(numberImpl)
+	return isNumber && !i.level && !otherNum.level
 }
 
 func (i numberImpl) Add(a, b Value) Value {
+	if bc, isComplex := b.ty.impl.(complexImpl); isComplex {
+		return bc.Add(a, b)
+	}
+
 	if a.IsUnknown() || b.IsUnknown() {
+		if i.isGain() {
+			return UnknownVal(b.Type())
+		}
 		return UnknownVal(a.Type())
 	}
 
+	if at, bt, tol := tolerancedOperands(a, b); tol {
+		return TolerancedQuantityVal(at.Add(bt))
+	}
+
 	av := a.v.(units.Quantity)
 	bv := b.v.(units.Quantity)
 	return QuantityVal(av.Add(bv))
 }
 
 func (i numberImpl) Subtract(a, b Value) Value {
+	if bc, isComplex := b.ty.impl.(complexImpl); isComplex {
+		return bc.Subtract(a, b)
+	}
+
 	if a.IsUnknown() || b.IsUnknown() {
+		if i.level && !b.ty.impl.(numberImpl).isGain() {
+			return UnknownVal(Gain)
+		}
 		return UnknownVal(a.Type())
 	}
 
+	if at, bt, tol := tolerancedOperands(a, b); tol {
+		return TolerancedQuantityVal(at.Subtract(bt))
+	}
+
 	av := a.v.(units.Quantity)
 	bv := b.v.(units.Quantity)
 	return QuantityVal(av.Subtract(bv))
 }
 
 func (i numberImpl) Multiply(a, b Value) Value {
+	if bc, isComplex := b.ty.impl.(complexImpl); isComplex {
+		return bc.Multiply(a, b)
+	}
+
 	if a.IsUnknown() || b.IsUnknown() {
 		retTy := Quantity(a.ty.impl.(numberImpl).dim.Multiply(b.ty.impl.(numberImpl).dim))
 		return UnknownVal(retTy)
 	}
 
+	if at, bt, tol := tolerancedOperands(a, b); tol {
+		return TolerancedQuantityVal(at.Multiply(bt))
+	}
+
 	av := a.v.(units.Quantity)
 	bv := b.v.(units.Quantity)
 	return QuantityVal(av.Multiply(bv))
 }
 
 func (i numberImpl) Divide(a, b Value) Value {
+	if bc, isComplex := b.ty.impl.(complexImpl); isComplex {
+		return bc.Divide(a, b)
+	}
+
 	if a.IsUnknown() || b.IsUnknown() {
 		retTy := Quantity(a.ty.impl.(numberImpl).dim.Multiply(b.ty.impl.(numberImpl).dim.Reciprocal()))
 		return UnknownVal(retTy)
 	}
 
+	if at, bt, tol := tolerancedOperands(a, b); tol {
+		return TolerancedQuantityVal(at.Divide(bt))
+	}
+
 	av := a.v.(units.Quantity)
 	bv := b.v.(units.Quantity)
 	return QuantityVal(av.Divide(bv))
 }
+
+// tolerancedOperands returns the two given known number values as toleranced
+// quantities if at least one of them is toleranced, or false as its third
+// result if both are exact.
+func tolerancedOperands(a, b Value) (units.TolerancedQuantity, units.TolerancedQuantity, bool) {
+	_, aTol := a.v.(units.TolerancedQuantity)
+	_, bTol := b.v.(units.TolerancedQuantity)
+	if !aTol && !bTol {
+		return units.TolerancedQuantity{}, units.TolerancedQuantity{}, false
+	}
+	return a.AsTolerancedQuantity(), b.AsTolerancedQuantity(), true
+}
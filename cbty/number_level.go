@@ -0,0 +1,38 @@
+package cbty
+
+import (
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// Level returns the type of logarithmic levels whose reference quantity
+// has the given dimensionality, such as power levels in dBm.
+//
+// Levels are numbers, but they are distinct from the linear quantity types
+// of the same dimensionality and cannot be mixed with them in arithmetic.
+// Levels with a dimensionless reference are gains.
+func Level(dim units.Dimensionality) Type {
+	return Type{numberImpl{dim: dim, level: true}}
+}
+
+// Gain is the type of dimensionless levels, such as an amplifier gain in dB.
+var Gain Type = Level(units.Dimensionality{})
+
+// PowerLevel is the type of power levels, such as in dBm or dBW.
+var PowerLevel Type = Level(Power.NumberDimensionality())
+
+// VoltageLevel is the type of voltage levels, such as in dBV.
+var VoltageLevel Type = Level(Voltage.NumberDimensionality())
+
+var levelTypeNames = map[units.Dimensionality]string{
+	units.Dimensionality{}: "Gain",
+}
+
+func init() {
+	levelTypeNames[PowerLevel.NumberDimensionality()] = "PowerLevel"
+	levelTypeNames[VoltageLevel.NumberDimensionality()] = "VoltageLevel"
+}
+
+// isGain returns true if the receiver is the Gain type.
+func (i numberImpl) isGain() bool {
+	return i.level && i.dim == units.Dimensionality{}
+}
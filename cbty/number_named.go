@@ -33,6 +33,9 @@ var namedNumberTypes = map[string]Type{
 	"LuminousIntensity": Quantity(units.Dimensionality{
 		LuminousIntensity: 1,
 	}),
+	"Temperature": Quantity(units.Dimensionality{
+		Temperature: 1,
+	}),
 
 	// Some derived dimensions get names too
 	"Force": Quantity(units.Dimensionality{
@@ -114,6 +117,12 @@ var namedNumberTypes = map[string]Type{
 		Length:            -2,
 		LuminousIntensity: 1,
 	}),
+	"ThermalResistance": Quantity(units.Dimensionality{
+		Mass:        -1,
+		Length:      -2,
+		Time:        3,
+		Temperature: 1,
+	}),
 }
 
 var numberTypeNames map[units.Dimensionality]string
@@ -67,6 +67,12 @@ var Resistivity Type = QuantityByName("Resistivity")
 // Speed is a quantity type of dimensionality [L][T]⁻¹.
 var Speed Type = QuantityByName("Speed")
 
+// Temperature is a quantity type of dimensionality [Θ].
+var Temperature Type = QuantityByName("Temperature")
+
+// ThermalResistance is a quantity type of dimensionality [Θ][T]³[M]⁻¹[L]⁻².
+var ThermalResistance Type = QuantityByName("ThermalResistance")
+
 // Time is a quantity type of dimensionality [T].
 var Time Type = QuantityByName("Time")
 
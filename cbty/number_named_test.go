@@ -32,9 +32,15 @@ func TestNumberTypeName(t *testing.T) {
 		{Resistance, "Resistance"},
 		{Resistivity, "Resistivity"},
 		{Speed, "Speed"},
+		{Temperature, "Temperature"},
+		{ThermalResistance, "ThermalResistance"},
 		{Time, "Time"},
 		{Voltage, "Voltage"},
 		{Quantity(units.Dimensionality{Length: -1}), "Quantity([L]⁻¹)"},
+		{Gain, "Gain"},
+		{PowerLevel, "PowerLevel"},
+		{VoltageLevel, "VoltageLevel"},
+		{Level(units.Dimensionality{ElectricCurrent: 1}), "Level([I])"},
 	}
 
 	for _, test := range tests {
@@ -100,6 +100,16 @@ func TestAddNumber(t *testing.T) {
 			UnknownVal(Length),
 			UnknownVal(Length),
 		},
+		{
+			testNumber("10", "dBm"),
+			testNumber("3", "dB"),
+			testNumber("13", "dBm"),
+		},
+		{
+			UnknownVal(Gain),
+			testNumber("10", "dBm"),
+			UnknownVal(PowerLevel),
+		},
 	}
 
 	for _, test := range tests {
@@ -142,6 +152,21 @@ func TestSubtractNumber(t *testing.T) {
 			UnknownVal(Length),
 			UnknownVal(Length),
 		},
+		{
+			testNumber("13", "dBm"),
+			testNumber("10", "dBm"),
+			testNumber("3", "dB"),
+		},
+		{
+			UnknownVal(PowerLevel),
+			testNumber("10", "dBm"),
+			UnknownVal(Gain),
+		},
+		{
+			UnknownVal(PowerLevel),
+			testNumber("3", "dB"),
+			UnknownVal(PowerLevel),
+		},
 	}
 
 	for _, test := range tests {
@@ -307,3 +332,145 @@ func testNumberU(n string, unit *units.Unit) Value {
 	q := units.MakeQuantity(num, unit)
 	return QuantityVal(q)
 }
+
+func TestNumberLevelArithmetic(t *testing.T) {
+	tests := []struct {
+		A, B        Type
+		CanSum      bool
+		CanSubtract bool
+		CanProduct  bool
+	}{
+		{PowerLevel, Gain, true, true, false},
+		{Gain, PowerLevel, true, false, false},
+		{Gain, Gain, true, true, false},
+		{PowerLevel, PowerLevel, false, true, false},
+		{PowerLevel, VoltageLevel, false, false, false},
+		{PowerLevel, Power, false, false, false},
+		{Gain, Number, false, false, false},
+		{Power, Power, true, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v and %#v", test.A, test.B), func(t *testing.T) {
+			if got := test.A.CanSum(test.B); got != test.CanSum {
+				t.Errorf("wrong CanSum %#v; want %#v", got, test.CanSum)
+			}
+			if got := test.A.CanSubtract(test.B); got != test.CanSubtract {
+				t.Errorf("wrong CanSubtract %#v; want %#v", got, test.CanSubtract)
+			}
+			if got := test.A.CanProduct(test.B); got != test.CanProduct {
+				t.Errorf("wrong CanProduct %#v; want %#v", got, test.CanProduct)
+			}
+		})
+	}
+}
+
+func TestTolerancedNumber(t *testing.T) {
+	r := TolerancedQuantityVal(testNumber("10", "kohm").AsQuantity().WithTolerance(big.NewFloat(0.01), big.NewFloat(0.01)))
+	if !r.Type().Same(Resistance) {
+		t.Fatalf("wrong type %#v; want %#v", r.Type(), Resistance)
+	}
+	if !r.IsToleranced() {
+		t.Fatalf("value is not toleranced")
+	}
+
+	tests := []struct {
+		Name string
+		Got  Value
+		Want string
+	}{
+		{"add", r.Add(testNumber("1", "kohm")), "11 kohm (10.9 to 11.1)"},
+		{"multiply", testNumber("2", "").Multiply(r), "20000 ohm (19800 to 20200)"},
+		{"divide", testNumber("5", "V").Divide(r.Divide(testNumber("1000", ""))), "0.5 A (0.495049505 to 0.5050505051)"},
+		{"nominal", QuantityVal(r.AsQuantity()), "10 kohm"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if got := test.Got.AsTolerancedQuantity().String(); got != test.Want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.Want)
+			}
+		})
+	}
+
+	if r.Equal(testNumber("10", "kohm")).True() {
+		t.Errorf("toleranced value equals its nominal value")
+	}
+	if !TolerancedQuantityVal(units.MakeExact(testNumber("10", "kohm").AsQuantity())).Same(testNumber("10", "kohm")) {
+		t.Errorf("exact toleranced value is not the same as the equivalent exact value")
+	}
+}
+
+func TestNumberConvertUnit(t *testing.T) {
+	r := TolerancedQuantityVal(testNumber("10", "kohm").AsQuantity().WithTolerance(big.NewFloat(0.01), big.NewFloat(0.01)))
+
+	tests := []struct {
+		Value Value
+		Unit  string
+		Want  string
+	}{
+		{testNumber("25.4", "mm"), "mil", "1000 mil"},
+		{testNumber("5", "V"), "mV", "5000 mV"},
+		{testNumber("30", "dBm"), "dBW", "0 dBW"},
+		{r, "ohm", "10000 ohm (9900 to 10100)"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v as %s", test.Value, test.Unit), func(t *testing.T) {
+			got := test.Value.ConvertUnit(units.ByName(test.Unit))
+			if !got.Type().Same(test.Value.Type()) {
+				t.Fatalf("wrong type %#v; want %#v", got.Type(), test.Value.Type())
+			}
+			if got := got.AsTolerancedQuantity().String(); got != test.Want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.Want)
+			}
+		})
+	}
+
+	if got := UnknownVal(Length).ConvertUnit(units.ByName("mil")); !got.Same(UnknownVal(Length)) {
+		t.Errorf("wrong result %#v; want unknown length", got)
+	}
+}
+
+func TestNumberValueIn(t *testing.T) {
+	tests := []struct {
+		Value Value
+		Unit  string
+		Want  string
+	}{
+		{testNumber("100", "mm"), "cm", "10"},
+		{testNumber("2", "A"), "mA", "2000"},
+		{TolerancedQuantityVal(testNumber("5", "V").AsQuantity().WithTolerance(big.NewFloat(0.05), big.NewFloat(0.05))), "mV", "5000 (4750 to 5250)"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v in %s", test.Value, test.Unit), func(t *testing.T) {
+			got := test.Value.ValueIn(units.ByName(test.Unit))
+			if !got.Type().Same(Number) {
+				t.Fatalf("wrong type %#v; want %#v", got.Type(), Number)
+			}
+			if got := got.AsTolerancedQuantity().String(); got != test.Want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.Want)
+			}
+		})
+	}
+
+	if got := UnknownVal(Length).ValueIn(units.ByName("mil")); !got.Same(UnknownVal(Number)) {
+		t.Errorf("wrong result %#v; want unknown number", got)
+	}
+}
+
+func TestNamedQuantity(t *testing.T) {
+	dim := units.Dimensionality{Mass: 1, Length: 2, Time: -3, Temperature: -1}
+	ty := NamedQuantity("ThermalConductance", dim)
+
+	if got, want := ty.Name(), "ThermalConductance"; got != want {
+		t.Errorf("wrong name %q; want %q", got, want)
+	}
+	if !ty.Same(Quantity(dim)) || !Quantity(dim).Same(ty) {
+		t.Errorf("named type is not the same as the unnamed type")
+	}
+	if ty.Same(Power) {
+		t.Errorf("named type is the same as a type of different dimensionality")
+	}
+}
@@ -57,8 +57,8 @@ func (t Type) HasArithmetic() bool {
 	return has
 }
 
-// CanSum returns true if the given type can support the Add and Subtract
-// operations with values of the other given type.
+// CanSum returns true if the given type can support the Add operation with
+// values of the other given type.
 //
 // Always returns false if the receiver doesn't support arithmetic at all.
 func (t Type) CanSum(o Type) bool {
@@ -68,6 +68,21 @@ func (t Type) CanSum(o Type) bool {
 	return t.impl.(typeWithArithmetic).CanSum(o)
 }
 
+// CanSubtract returns true if the given type can support the Subtract
+// operation with values of the other given type.
+//
+// This is usually the same as CanSum, but differs for some types such as
+// logarithmic levels, where the difference of two levels is meaningful but
+// their sum is not.
+//
+// Always returns false if the receiver doesn't support arithmetic at all.
+func (t Type) CanSubtract(o Type) bool {
+	if !t.HasArithmetic() {
+		return false
+	}
+	return t.impl.(typeWithArithmetic).CanSubtract(o)
+}
+
 // CanProduct returns true if the given type can support the Multiply and Divide
 // operations with values of the other given type.
 //
@@ -149,8 +164,25 @@ func (t Type) IsNumber() bool {
 	return isNumber
 }
 
+// IsLevel returns true if the receiver is a logarithmic level type, such
+// as Gain or PowerLevel. Level types are also number types.
+func (t Type) IsLevel() bool {
+	impl, isNumber := t.impl.(numberImpl)
+	return isNumber && impl.level
+}
+
+// IsComplex returns true if the receiver is a complex quantity type, such
+// as Impedance. Complex quantity types are not number types.
+func (t Type) IsComplex() bool {
+	_, isComplex := t.impl.(complexImpl)
+	return isComplex
+}
+
 // NumberDimensionality returns the dimensionality of the receiving number
 // type, or panics if the receiver is not a number type.
+//
+// For level types, the result is the dimensionality of the reference
+// quantity.
 func (t Type) NumberDimensionality() units.Dimensionality {
 	impl, isNumber := t.impl.(numberImpl)
 	if !isNumber {
@@ -160,6 +192,17 @@ func (t Type) NumberDimensionality() units.Dimensionality {
 	return impl.dim
 }
 
+// ComplexDimensionality returns the dimensionality of the receiving complex
+// quantity type, or panics if the receiver is not a complex quantity type.
+func (t Type) ComplexDimensionality() units.Dimensionality {
+	impl, isComplex := t.impl.(complexImpl)
+	if !isComplex {
+		panic("ComplexDimensionality on non-complex type")
+	}
+
+	return impl.dim
+}
+
 type typeImpl interface {
 	typeSigil() isType
 	Name() string
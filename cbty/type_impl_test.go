@@ -15,4 +15,6 @@ func TestTypeImplInterfaces(t *testing.T) {
 	// Specific type implementations
 	var _ typeImpl = numberImpl{}
 	var _ typeWithArithmetic = numberImpl{}
+	var _ typeImpl = complexImpl{}
+	var _ typeWithArithmetic = complexImpl{}
 }
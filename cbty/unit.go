@@ -0,0 +1,36 @@
+package cbty
+
+import (
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// unitImpl is the typeImpl for units of measure. Units are values so that
+// they can be defined within Cirbo packages and imported by others.
+type unitImpl struct {
+	isType
+}
+
+// Unit is the type of units of measure, such as millimeters.
+var Unit Type
+
+func UnitVal(u *units.Unit) Value {
+	return Value{
+		v:  u,
+		ty: Unit,
+	}
+}
+
+func (i unitImpl) Name() string {
+	return "Unit"
+}
+
+func (i unitImpl) Equal(a, b Value) Value {
+	// Named units are singletons, so we can compare them by identity.
+	av := a.v.(*units.Unit)
+	bv := b.v.(*units.Unit)
+	return BoolVal(av == bv)
+}
+
+func init() {
+	Unit = Type{unitImpl{}}
+}
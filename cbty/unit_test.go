@@ -0,0 +1,21 @@
+package cbty
+
+import (
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+func TestUnitEqual(t *testing.T) {
+	mm := UnitVal(units.ByName("mm"))
+
+	if !mm.Equal(UnitVal(units.ByName("mm"))).True() {
+		t.Errorf("mm is not equal to mm")
+	}
+	if mm.Equal(UnitVal(units.ByName("mil"))).True() {
+		t.Errorf("mm is equal to mil")
+	}
+	if got := mm.AsUnit(); got != units.ByName("mm") {
+		t.Errorf("wrong unit %#v", got)
+	}
+}
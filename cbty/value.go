@@ -105,8 +105,23 @@ func (v Value) AsString() string {
 	return v.v.(string)
 }
 
+// AsUnit returns the *units.Unit value of the receiver if it is known and
+// of type Unit, or panics otherwise.
+func (v Value) AsUnit() *units.Unit {
+	if v.IsUnknown() {
+		panic("AsUnit on unknown value")
+	}
+	if !v.Type().Same(Unit) {
+		panic("AsUnit on non-unit value")
+	}
+	return v.v.(*units.Unit)
+}
+
 // AsQuantity returns the units.Quantity value of the receiver if it is known
 // and of a number type, or panics otherwise.
+//
+// If the receiver is toleranced, the result is its nominal value. Use
+// AsTolerancedQuantity to also obtain its range.
 func (v Value) AsQuantity() units.Quantity {
 	if v.IsUnknown() {
 		panic("AsQuantity on unknown value")
@@ -114,9 +129,36 @@ func (v Value) AsQuantity() units.Quantity {
 	if !v.Type().IsNumber() {
 		panic("AsQuantity on non-number value")
 	}
+	if tq, tol := v.v.(units.TolerancedQuantity); tol {
+		return tq.Nom()
+	}
 	return v.v.(units.Quantity)
 }
 
+// AsTolerancedQuantity returns the units.TolerancedQuantity value of the
+// receiver if it is known and of a number type, or panics otherwise.
+//
+// If the receiver is exact, the result has no tolerance.
+func (v Value) AsTolerancedQuantity() units.TolerancedQuantity {
+	if v.IsUnknown() {
+		panic("AsTolerancedQuantity on unknown value")
+	}
+	if !v.Type().IsNumber() {
+		panic("AsTolerancedQuantity on non-number value")
+	}
+	if tq, tol := v.v.(units.TolerancedQuantity); tol {
+		return tq
+	}
+	return units.MakeExact(v.v.(units.Quantity))
+}
+
+// IsToleranced returns true if the receiver is a known number value with a
+// tolerance, as created by TolerancedQuantityVal.
+func (v Value) IsToleranced() bool {
+	_, tol := v.v.(units.TolerancedQuantity)
+	return tol
+}
+
 // IsKnown returns true if the receiver is a known value.
 //
 // If false is returned, only the type is known.
@@ -152,7 +194,7 @@ func (v Value) Add(o Value) Value {
 // This function will panic if the value type does not support arithmetic or
 // cannot subtract a value of the other type.
 func (v Value) Subtract(o Value) Value {
-	if !v.Type().CanSum(o.Type()) {
+	if !v.Type().CanSubtract(o.Type()) {
 		panic(fmt.Errorf("attempt to subtract %#v from %#v", v.Type(), o.Type()))
 	}
 
@@ -328,6 +370,9 @@ func (v Value) GoString() string {
 		case One:
 			return "cty.One"
 		default:
+			if tq, tol := v.v.(units.TolerancedQuantity); tol {
+				return fmt.Sprintf("cty.TolerancedQuantityVal(units.MakeTolerancedQuantity(%#v, %#v, %#v))", tq.Min(), tq.Nom(), tq.Max())
+			}
 			quantity := v.v.(units.Quantity)
 			if quantity.Unit().Dimensionality() == (units.Dimensionality{}) {
 				if iv, acc := quantity.Value().Int64(); acc == big.Exact {
@@ -339,6 +384,8 @@ func (v Value) GoString() string {
 			}
 			return fmt.Sprintf("cty.QuantityVal(%#v)", quantity)
 		}
+	case v.Type().IsComplex():
+		return fmt.Sprintf("cty.ComplexQuantityVal(%#v)", v.v)
 	case v == True:
 		return "cty.True"
 	case v == False:
@@ -347,6 +394,8 @@ func (v Value) GoString() string {
 		return fmt.Sprintf("cty.StringVal(%q)", v.v)
 	case v.Type().Same(TypeType):
 		return fmt.Sprintf("cty.TypeTypeVal(%#v)", v.v)
+	case v.Type().Same(Unit):
+		return fmt.Sprintf("cty.UnitVal(%#v)", v.v)
 	default:
 		return fmt.Sprintf("cty.Value{ty: %#v, v: %#v}", v.ty, v.v)
 	}
@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/cirbo-lang/cirbo/cbty"
 	"github.com/cirbo-lang/cirbo/units"
@@ -37,28 +38,14 @@ func compileExpr(node ast.Node, scope *eval.Scope, swap ast.SwapTable) (eval.Exp
 		tv := cbty.StringVal(tn.Value)
 		return eval.LiteralExpr(tv, tn.SourceRange()), nil
 	case *ast.NumberLit:
-		unitName := tn.Unit
-		if unitName == "" {
-			tv := cbty.QuantityVal(units.MakeDimensionless(tn.Value))
-			return eval.LiteralExpr(tv, tn.SourceRange()), nil
+		if tn.Imaginary {
+			return compileImaginary(units.MakeQuantity(tn.Value, units.ByName(tn.Unit)), tn)
 		}
-		unit := units.ByName(tn.Unit)
-		if unit == nil {
-			suggestion := nameSuggestion(tn.Unit, units.AllNames())
-			if suggestion != "" {
-				suggestion = fmt.Sprintf(" Did you mean %q?", suggestion)
-			}
-			return placeholderExpr(tn.SourceRange()), source.Diags{
-				{
-					Level:   source.Error,
-					Summary: "Invalid unit",
-					Detail:  fmt.Sprintf("The name %q is not a known quantity unit.%s", tn.Unit, suggestion),
-					Ranges:  tn.SourceRange().List(),
-				},
-			}
+		nom, diags := compileQuantity(tn.Value, tn.Unit, scope, tn.SourceRange())
+		if tn.Tolerance != nil {
+			return compileTolerance(nom, tn.Tolerance, scope, tn.SourceRange(), diags)
 		}
-		tv := cbty.QuantityVal(units.MakeQuantity(tn.Value, unit))
-		return eval.LiteralExpr(tv, tn.SourceRange()), nil
+		return nom, diags
 	case *ast.Variable:
 		sym := scope.Get(tn.Name)
 		if sym == nil {
@@ -126,6 +113,14 @@ func compileExpr(node ast.Node, scope *eval.Scope, swap ast.SwapTable) (eval.Exp
 		default:
 			panic(fmt.Errorf("compilation of unary %s is not implemented", tn.Op))
 		}
+	case *ast.Conversion:
+		operand, diags := compileExpr(tn.Operand, scope, swap)
+		unit, unitDiags := compileUnit(tn.Unit, scope, tn.UnitRange)
+		diags = append(diags, unitDiags...)
+		if unitDiags.HasErrors() {
+			return placeholderExpr(tn.SourceRange()), diags
+		}
+		return eval.ConversionExpr(operand, unit, tn.SourceRange()), diags
 	case *ast.GetAttr:
 		obj, diags := compileExpr(tn.Source, scope, swap)
 		return eval.AttrExpr(obj, tn.Name, tn.SourceRange()), diags
@@ -184,3 +179,117 @@ func compileExpr(node ast.Node, scope *eval.Scope, swap ast.SwapTable) (eval.Exp
 func placeholderExpr(rng source.Range) eval.Expr {
 	return eval.LiteralExpr(cbty.PlaceholderVal, rng)
 }
+
+// compileQuantity returns an expression that produces a quantity of the
+// given value in the unit of the given name, which may be either a built-in
+// unit or a unit declared in a package. An empty name means a dimensionless
+// number.
+func compileQuantity(value *big.Float, unitName string, scope *eval.Scope, rng source.Range) (eval.Expr, source.Diags) {
+	if unitName == "" {
+		return eval.LiteralExpr(cbty.QuantityVal(units.MakeQuantity(value, nil)), rng), nil
+	}
+	if unit := units.ByName(unitName); unit != nil {
+		return eval.LiteralExpr(cbty.QuantityVal(units.MakeQuantity(value, unit)), rng), nil
+	}
+
+	// The unit might instead be declared in a package, in which case it
+	// isn't known until evaluation.
+	unitExpr, diags := compileUnit(unitName, scope, rng)
+	if diags.HasErrors() {
+		return placeholderExpr(rng), diags
+	}
+	return eval.QuantityExpr(value, unitExpr, rng), diags
+}
+
+// compileTolerance returns an expression that applies the given tolerance
+// from a number literal to the nominal value produced by the given
+// expression, which was compiled from the same literal.
+func compileTolerance(nom eval.Expr, tol *ast.Tolerance, scope *eval.Scope, rng source.Range, diags source.Diags) (eval.Expr, source.Diags) {
+	if tol.Minus == nil || tol.Plus == nil {
+		// The parser will already have reported the problem.
+		return nom, diags
+	}
+
+	if tol.Relative {
+		minus := eval.LiteralExpr(cbty.QuantityVal(units.MakeQuantity(tol.Minus, nil)), rng)
+		plus := eval.LiteralExpr(cbty.QuantityVal(units.MakeQuantity(tol.Plus, nil)), rng)
+		return eval.ToleranceExpr(nom, minus, plus, true, rng), diags
+	}
+
+	minus, unitDiags := compileQuantity(tol.Minus, tol.Unit, scope, rng)
+	diags = append(diags, unitDiags...)
+	if unitDiags.HasErrors() {
+		return placeholderExpr(rng), diags
+	}
+	// Both bounds share a unit, so any problem is already reported above.
+	plus, _ := compileQuantity(tol.Plus, tol.Unit, scope, rng)
+	return eval.ToleranceExpr(nom, minus, plus, false, rng), diags
+}
+
+// compileImaginary returns an expression that produces the imaginary value
+// of the given number literal, whose nominal value is the given quantity.
+func compileImaginary(q units.Quantity, lit *ast.NumberLit) (eval.Expr, source.Diags) {
+	rng := lit.SourceRange()
+	switch {
+	case lit.Tolerance != nil:
+		return placeholderExpr(rng), source.Diags{
+			{
+				Level:   source.Error,
+				Summary: "Invalid tolerance",
+				Detail:  "A tolerance cannot be given for an imaginary value.",
+				Ranges:  rng.List(),
+			},
+		}
+	case q.Unit().Logarithmic():
+		return placeholderExpr(rng), source.Diags{
+			{
+				Level:   source.Error,
+				Summary: "Invalid unit",
+				Detail:  fmt.Sprintf("An imaginary value cannot be given in %s, because it is a logarithmic unit.", q.Unit()),
+				Ranges:  rng.List(),
+			},
+		}
+	case q.Unit().OffsetTemperature():
+		return placeholderExpr(rng), source.Diags{
+			{
+				Level:   source.Error,
+				Summary: "Invalid unit",
+				Detail:  fmt.Sprintf("An imaginary value cannot be given in %s, because it is a unit of absolute temperature.", q.Unit()),
+				Ranges:  rng.List(),
+			},
+		}
+	}
+
+	return eval.LiteralExpr(cbty.ComplexQuantityVal(units.MakeImaginary(q)), rng), nil
+}
+
+// compileUnit returns an expression that produces the unit with the given
+// name, which is either a built-in unit or a unit declared in the given
+// scope using a "unit" statement.
+func compileUnit(name string, scope *eval.Scope, rng source.Range) (eval.Expr, source.Diags) {
+	if unit := units.ByName(name); unit != nil {
+		return eval.LiteralExpr(cbty.UnitVal(unit), rng), nil
+	}
+	sym := scope.Get(name)
+	if sym == nil {
+		return placeholderExpr(rng), invalidUnitDiags(name, rng)
+	}
+	return eval.SymbolExpr(sym, rng), nil
+}
+
+// invalidUnitDiags returns an error diagnostic for a number literal that
+// uses the given unrecognized unit name.
+func invalidUnitDiags(name string, rng source.Range) source.Diags {
+	suggestion := nameSuggestion(name, units.AllNames())
+	if suggestion != "" {
+		suggestion = fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+	return source.Diags{
+		{
+			Level:   source.Error,
+			Summary: "Invalid unit",
+			Detail:  fmt.Sprintf("The name %q is not a known quantity unit.%s", name, suggestion),
+			Ranges:  rng.List(),
+		},
+	}
+}
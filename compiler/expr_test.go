@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"math"
 	"testing"
 
 	"github.com/cirbo-lang/cirbo/cbty"
@@ -11,6 +12,11 @@ import (
 )
 
 func TestCompileExpr(t *testing.T) {
+	ozCu, err := units.DefineUnit("oz_cu", units.MakeQuantityFloat(34.79, units.ByName("um")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []struct {
 		Source string
 		Want   cbty.Value
@@ -36,11 +42,284 @@ func TestCompileExpr(t *testing.T) {
 			cbty.NumberValFloat(0.5),
 			0,
 		},
+		{
+			"500000ppm",
+			cbty.NumberValFloat(0.5),
+			0,
+		},
 		{
 			"5mm",
 			cbty.QuantityVal(units.MakeQuantityInt(5, units.ByName("mm"))),
 			0,
 		},
+		{
+			"5V ±250mV",
+			cbty.TolerancedQuantityVal(units.MakeTolerancedQuantity(
+				units.MakeQuantityFloat(4.75, units.ByName("V")),
+				units.MakeQuantityInt(5, units.ByName("V")),
+				units.MakeQuantityFloat(5.25, units.ByName("V")),
+			)),
+			0,
+		},
+		{
+			"2V ±50% * 2",
+			cbty.TolerancedQuantityVal(units.MakeTolerancedQuantity(
+				units.MakeQuantityInt(2, units.ByName("V")),
+				units.MakeQuantityInt(4, units.ByName("V")),
+				units.MakeQuantityInt(6, units.ByName("V")),
+			)),
+			0,
+		},
+		{
+			"5V ±1mm",
+			cbty.PlaceholderVal,
+			1, // tolerance not commensurable with value
+		},
+		{
+			"10V / (5V ±100%)",
+			cbty.UnknownVal(cbty.Number),
+			1, // range of divisor includes zero
+		},
+		{
+			"(1V ±10%) / 0V",
+			cbty.UnknownVal(cbty.Number),
+			1, // range of divisor includes zero
+		},
+		{
+			"125degC - 25degC",
+			cbty.QuantityVal(units.MakeQuantityInt(100, units.ByName("K"))),
+			0,
+		},
+		{
+			"(125degC - 25degC) / (40K / 1W)",
+			cbty.QuantityVal(units.MakeQuantityFloat(2.5, units.ByName("W"))),
+			0,
+		},
+		{
+			"25degC + 2W * (40K / 1W)",
+			cbty.QuantityVal(units.MakeQuantityInt(105, units.ByName("degC"))),
+			0,
+		},
+		{
+			"10dBm ±1dB",
+			cbty.TolerancedQuantityVal(units.MakeTolerancedQuantity(
+				units.MakeQuantityInt(9, units.ByName("dBm")),
+				units.MakeQuantityInt(10, units.ByName("dBm")),
+				units.MakeQuantityInt(11, units.ByName("dBm")),
+			)),
+			0,
+		},
+		{
+			"10dBm ±1mW",
+			cbty.PlaceholderVal,
+			1, // a linear tolerance cannot apply to a level
+		},
+		{
+			"25degC ±9degF",
+			cbty.TolerancedQuantityVal(units.MakeTolerancedQuantity(
+				units.MakeQuantityInt(20, units.ByName("degC")),
+				units.MakeQuantityInt(25, units.ByName("degC")),
+				units.MakeQuantityInt(30, units.ByName("degC")),
+			)),
+			0,
+		},
+		{
+			"25degC + 18degF",
+			cbty.PlaceholderVal,
+			1, // cannot add two absolute temperatures
+		},
+		{
+			"2 * 25degC",
+			cbty.PlaceholderVal,
+			1, // cannot multiply an absolute temperature
+		},
+		{
+			"5V as mV",
+			cbty.QuantityVal(units.MakeQuantityInt(5000, units.ByName("mV"))),
+			0,
+		},
+		{
+			"(1m + 50cm) as mm",
+			cbty.QuantityVal(units.MakeQuantityInt(1500, units.ByName("mm"))),
+			0,
+		},
+		{
+			"5V as mm",
+			cbty.UnknownVal(cbty.Length),
+			1, // voltage cannot be expressed in mm
+		},
+		{
+			"foo as mm",
+			cbty.UnknownVal(cbty.Length),
+			1, // strings cannot be converted
+		},
+		{
+			`value_in(5V, "mV")`,
+			cbty.NumberValInt(5000),
+			0,
+		},
+		{
+			`value_in(5V, "mm")`,
+			cbty.UnknownVal(cbty.Number),
+			1, // voltage cannot be expressed in mm
+		},
+		{
+			`value_in(5V)`,
+			cbty.UnknownVal(cbty.Number),
+			1, // unit name is required
+		},
+		{
+			"2oz_cu",
+			cbty.QuantityVal(units.MakeQuantityInt(2, ozCu)),
+			0,
+		},
+		{
+			"2oz_cu ±50%",
+			cbty.TolerancedQuantityVal(units.MakeTolerancedQuantity(
+				units.MakeQuantityInt(1, ozCu),
+				units.MakeQuantityInt(2, ozCu),
+				units.MakeQuantityInt(3, ozCu),
+			)),
+			0,
+		},
+		{
+			"2oz_cu ±1oz_cu",
+			cbty.TolerancedQuantityVal(units.MakeTolerancedQuantity(
+				units.MakeQuantityInt(1, ozCu),
+				units.MakeQuantityInt(2, ozCu),
+				units.MakeQuantityInt(3, ozCu),
+			)),
+			0,
+		},
+		{
+			"2oz_cu ±1V",
+			cbty.PlaceholderVal,
+			1, // tolerance not commensurable with value
+		},
+		{
+			`value_in(2oz_cu, "oz_cu")`,
+			cbty.NumberValInt(2),
+			0,
+		},
+		{
+			"2oz_cu as oz_cu",
+			cbty.QuantityVal(units.MakeQuantityInt(2, ozCu)),
+			0,
+		},
+		{
+			"5V as oz_cu",
+			cbty.UnknownVal(cbty.Length),
+			1, // voltage cannot be expressed in oz_cu
+		},
+		{
+			"5V as foo",
+			cbty.PlaceholderVal,
+			1, // foo is not a unit
+		},
+		{
+			"2nonunit",
+			cbty.PlaceholderVal,
+			1, // nonunit is not declared
+		},
+		{
+			"30j ohm",
+			cbty.ComplexQuantityVal(units.MakeImaginary(units.MakeQuantityInt(30, units.ByName("ohm")))),
+			0,
+		},
+		{
+			"50ohm + 30j ohm",
+			cbty.ComplexQuantityVal(units.MakeComplexQuantity(
+				units.MakeQuantityInt(50, units.ByName("ohm")),
+				units.MakeQuantityInt(30, units.ByName("ohm")),
+			)),
+			0,
+		},
+		{
+			"(30ohm + 40j ohm) as mohm",
+			cbty.ComplexQuantityVal(units.MakeComplexQuantity(
+				units.MakeQuantityInt(30000, units.ByName("mohm")),
+				units.MakeQuantityInt(40000, units.ByName("mohm")),
+			)),
+			0,
+		},
+		{
+			"10kohm ±1% + 5j ohm",
+			cbty.PlaceholderVal,
+			1, // complex quantities cannot have tolerances
+		},
+		{
+			"1ohm / (0j ohm)",
+			cbty.UnknownVal(cbty.Complex),
+			1, // complex division by zero
+		},
+		{
+			`value_in(30ohm + 40j ohm, "mohm")`,
+			cbty.ComplexQuantityVal(units.MakeComplexQuantity(
+				units.MakeDimensionlessInt(30000),
+				units.MakeDimensionlessInt(40000),
+			)),
+			0,
+		},
+		{
+			"Z_C(100nF ±20%, 1kHz)",
+			cbty.UnknownVal(cbty.Impedance),
+			1, // complex quantities cannot have tolerances
+		},
+		{
+			"abs(5V ±1%)",
+			cbty.PlaceholderVal,
+			1, // complex quantities cannot have tolerances
+		},
+		{
+			"30j ±1%",
+			cbty.PlaceholderVal,
+			1, // imaginary values cannot have tolerances
+		},
+		{
+			"30j dBm",
+			cbty.PlaceholderVal,
+			1, // imaginary values cannot be logarithmic
+		},
+		{
+			"abs(30ohm + 40j ohm)",
+			cbty.QuantityVal(units.MakeQuantityInt(50, units.ByName("ohm"))),
+			0,
+		},
+		{
+			"re(30ohm - 40j ohm)",
+			cbty.QuantityVal(units.MakeQuantityInt(30, units.ByName("ohm"))),
+			0,
+		},
+		{
+			"im(30ohm - 40j ohm)",
+			cbty.QuantityVal(units.MakeQuantityInt(-40, units.ByName("ohm"))),
+			0,
+		},
+		{
+			"abs(foo)",
+			cbty.PlaceholderVal,
+			1, // strings are not complex quantities
+		},
+		{
+			"Z_L(1H, 1Hz)",
+			cbty.ComplexQuantityVal(units.MakeImaginary(units.MakeQuantityFloat(2*math.Pi, units.ByName("ohm")))),
+			0,
+		},
+		{
+			"Z_C(1F, 1Hz) * Z_L(1H, 1Hz)",
+			cbty.ComplexQuantityVal(units.MakeReal(units.MakeQuantityInt(1, units.ByName("ohm").Multiply(units.ByName("ohm"))))),
+			0,
+		},
+		{
+			"Z_C(1uF, 0Hz)",
+			cbty.UnknownVal(cbty.Impedance),
+			1, // impedance is infinite
+		},
+		{
+			"Z_C(1uH, 1kHz)",
+			cbty.UnknownVal(cbty.Impedance),
+			1, // inductance is not capacitance
+		},
 		{
 			"foo",
 			cbty.StringVal("foo"),
@@ -129,10 +408,12 @@ func TestCompileExpr(t *testing.T) {
 	barSym := scope2.Declare("bar")
 	upperSym := scope1.Declare("upper")
 	scope1.Declare("notDefined")
+	ozCuSym := scope1.Declare("oz_cu")
 
 	ctx := eval.GlobalContext().NewChild()
 	ctx.DefineLiteral(fooSym, cbty.StringVal("foo"))
 	ctx.DefineLiteral(barSym, cbty.StringVal("bar"))
+	ctx.DefineLiteral(ozCuSym, cbty.UnitVal(ozCu))
 	ctx.DefineLiteral(upperSym, cbty.FunctionVal(cbty.FunctionImpl{
 		Signature: &cbty.CallSignature{
 			Parameters: map[string]cbty.CallParameter{
@@ -20,7 +20,7 @@ func CompilePackage(pkg ast.Package) (*eval.Package, source.Diags) {
 	for _, file := range pkg {
 		for _, node := range file.TopLevel {
 			switch tn := node.(type) {
-			case *ast.Assign, *ast.Import, *ast.Export, *ast.Circuit, *ast.Device, *ast.Land, *ast.Pinout:
+			case *ast.Assign, *ast.Import, *ast.Export, *ast.UnitDecl, *ast.DimensionDecl, *ast.Circuit, *ast.Device, *ast.Land, *ast.Pinout:
 				// allowed
 			case *ast.Connection:
 				diags = append(diags, source.Diag{
@@ -81,6 +81,14 @@ func compileStatement(node ast.Node, scope *eval.Scope, swap ast.SwapTable) (eva
 	case *ast.Export:
 		expr, diags := compileExpr(tn.Value, scope, swap)
 		return eval.ExportStmt(expr, tn.SourceRange()), diags
+	case *ast.UnitDecl:
+		expr, diags := compileExpr(tn.Value, scope, swap)
+		sym := scope.Get(tn.Name)
+		return eval.UnitStmt(sym, expr, tn.SourceRange()), diags
+	case *ast.DimensionDecl:
+		expr, diags := compileExpr(tn.Value, scope, swap)
+		sym := scope.Get(tn.Name)
+		return eval.DimensionStmt(sym, expr, tn.SourceRange()), diags
 	case *ast.Attr:
 		sym := scope.Get(tn.Name)
 
@@ -136,6 +144,16 @@ func declForNode(node ast.Node) symbolDecl {
 			Name:  tn.SymbolName(),
 			Range: tn.SourceRange(),
 		}
+	case *ast.UnitDecl:
+		return symbolDecl{
+			Name:  tn.Name,
+			Range: tn.SourceRange(),
+		}
+	case *ast.DimensionDecl:
+		return symbolDecl{
+			Name:  tn.Name,
+			Range: tn.SourceRange(),
+		}
 	case *ast.Attr:
 		return symbolDecl{
 			Name:  tn.Name,
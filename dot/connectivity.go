@@ -0,0 +1,242 @@
+package dot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// endpointRef describes where a particular endpoint appears in the
+// connectivity graph, as a DOT node id optionally qualified by a port name.
+type endpointRef struct {
+	Node string
+	Port string
+}
+
+func (r endpointRef) String() string {
+	if r.Port == "" {
+		return quote(r.Node)
+	}
+	return fmt.Sprintf("%s:%s", quote(r.Node), quote(r.Port))
+}
+
+func writeConnectivity(gw *graphWriter, root *cbo.CircuitInstance, colorERC bool) {
+	gw.Open("digraph %s", quote("cirbo"))
+	gw.Line("rankdir=LR;")
+	gw.Line("node [shape=record];")
+
+	refs := map[*cbo.Endpoint]endpointRef{}
+
+	// We'll collect the nets in the order we first encounter them, along
+	// with the endpoints we know how to draw, so that the result is
+	// deterministic even though nets themselves are unordered sets.
+	var nets []*cbo.Net
+	netEndpoints := map[*cbo.Net][]*cbo.Endpoint{}
+	addEndpoint := func(ep *cbo.Endpoint, ref endpointRef) {
+		refs[ep] = ref
+		if ep.Net == nil {
+			return
+		}
+		if _, seen := netEndpoints[ep.Net]; !seen {
+			nets = append(nets, ep.Net)
+		}
+		netEndpoints[ep.Net] = append(netEndpoints[ep.Net], ep)
+	}
+
+	nextCluster := 0
+	nextNode := 0
+
+	var writeCircuit func(path cbo.InstancePath, inst *cbo.CircuitInstance)
+	writeCircuit = func(path cbo.InstancePath, inst *cbo.CircuitInstance) {
+		gw.Open("subgraph %s", quote(fmt.Sprintf("cluster_%d", nextCluster)))
+		nextCluster++
+
+		name := path.String()
+		if name == "" {
+			name = inst.Name
+		}
+		gw.Line("label=%s;", quote(instanceLabel(name, circuitName(inst))))
+
+		// Each endpoint of each of the circuit's own terminals is shown as
+		// a separate "port" node. Both the inside and outside endpoints
+		// are attached to this node, so it serves to show how the
+		// nets inside the circuit relate to the nets outside.
+		for _, termName := range inst.TerminalNames() {
+			term := inst.Terminals[termName]
+			for i := range term.Inside {
+				nodeID := fmt.Sprintf("p%d", nextNode)
+				nextNode++
+				epName := term.EndpointName(i)
+				if len(path) > 0 {
+					epName = path.Child(epName).String()
+				}
+				gw.Line("%s [shape=cds, label=%s];", quote(nodeID), quote(epName))
+				addEndpoint(term.Inside[i], endpointRef{Node: nodeID})
+				if i < len(term.Outside) {
+					addEndpoint(term.Outside[i], endpointRef{Node: nodeID})
+				}
+			}
+		}
+
+		for _, devName := range inst.DeviceNames() {
+			dev := inst.Devices[devName]
+			nodeID := fmt.Sprintf("d%d", nextNode)
+			nextNode++
+
+			var ports []string
+			for _, termName := range dev.TerminalNames() {
+				term := dev.Terminals[termName]
+				for i, ep := range term.Outside {
+					portID := fmt.Sprintf("t%d", len(ports))
+					ports = append(ports, fmt.Sprintf("<%s>%s", portID, recordEscaper.Replace(term.EndpointName(i))))
+					addEndpoint(ep, endpointRef{Node: nodeID, Port: portID})
+				}
+			}
+
+			label := recordEscaper.Replace(instanceLabel(path.Child(devName).String(), deviceName(dev)))
+			if len(ports) > 0 {
+				label = fmt.Sprintf("{%s|{%s}}", label, strings.Join(ports, "|"))
+			}
+			gw.Line("%s [label=%s];", quote(nodeID), quote(label))
+		}
+
+		for _, circName := range inst.CircuitNames() {
+			writeCircuit(path.Child(circName), inst.Circuits[circName])
+		}
+
+		gw.Close()
+	}
+	writeCircuit(nil, root)
+
+	for i, net := range nets {
+		eps := netEndpoints[net]
+		if len(eps) < 2 {
+			// An endpoint alone on its net is not connected to anything,
+			// so there is nothing to draw.
+			continue
+		}
+		name := net.SuggestedName()
+
+		if len(eps) == 2 {
+			a, b := eps[0], eps[1]
+			attrs := []string{
+				"dir=" + edgeDir(a, b, colorERC),
+			}
+			if name != "" {
+				attrs = append(attrs, "label="+quote(name))
+			}
+			if colorERC {
+				attrs = append(attrs, ercEdgeAttrs(a, b)...)
+			}
+			gw.Line("%s -> %s [%s];", refs[a], refs[b], strings.Join(attrs, ", "))
+			continue
+		}
+
+		// For nets with more than two endpoints we create a separate node
+		// to represent the net itself, and connect each endpoint to it.
+		netID := fmt.Sprintf("net%d", i)
+		if name != "" {
+			gw.Line("%s [shape=ellipse, label=%s];", quote(netID), quote(name))
+		} else {
+			gw.Line("%s [shape=point];", quote(netID))
+		}
+		for _, ep := range eps {
+			attrs := []string{
+				"dir=" + edgeDir(ep, nil, colorERC),
+			}
+			if colorERC {
+				attrs = append(attrs, ercEdgeAttrs(ep, nil)...)
+			}
+			gw.Line("%s -> %s [%s];", refs[ep], quote(netID), strings.Join(attrs, ", "))
+		}
+	}
+
+	gw.Close()
+}
+
+// edgeDir returns the DOT "dir" attribute value for an edge from endpoint
+// a to endpoint b, where b may be nil to represent a hyperedge net node.
+//
+// When ERC colouring is not enabled, all edges are undirected.
+func edgeDir(a, b *cbo.Endpoint, colorERC bool) string {
+	if !colorERC {
+		return "none"
+	}
+
+	var aOut, aIn, bOut, bIn bool
+	aOut, aIn = endpointDirs(a)
+	if b != nil {
+		bOut, bIn = endpointDirs(b)
+	} else {
+		// The net node itself behaves as the opposite of its endpoint, so
+		// that outputs point towards the net and the net points towards
+		// inputs.
+		bOut, bIn = aIn, aOut
+	}
+
+	forward := aOut && bIn
+	back := bOut && aIn
+	switch {
+	case forward && back:
+		return "both"
+	case forward:
+		return "forward"
+	case back:
+		return "back"
+	default:
+		return "none"
+	}
+}
+
+func endpointDirs(ep *cbo.Endpoint) (out, in bool) {
+	switch ep.ERC.Dir {
+	case cbo.Output:
+		return true, false
+	case cbo.Input:
+		return false, true
+	case cbo.Bidirectional:
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// ercEdgeAttrs returns DOT attributes to colour and style an edge between
+// the two given endpoints, where b may be nil to represent a hyperedge net
+// node.
+func ercEdgeAttrs(a, b *cbo.Endpoint) []string {
+	color := ercTypeColor(a.ERC.Type)
+	if b != nil && b.ERC.Type != a.ERC.Type {
+		// Graphviz draws parallel lines for a colon-separated color list,
+		// which makes it easy to see when two different types are mixed.
+		color = color + ":" + ercTypeColor(b.ERC.Type)
+	}
+	ret := []string{"color=" + quote(color)}
+
+	if isFlagDir(a.ERC.Dir) || (b != nil && isFlagDir(b.ERC.Dir)) {
+		ret = append(ret, "style=dashed")
+	}
+
+	return ret
+}
+
+func ercTypeColor(ty cbo.ERCType) string {
+	switch ty {
+	case cbo.Power:
+		return "red"
+	case cbo.Signal:
+		return "blue"
+	default:
+		return "gray40"
+	}
+}
+
+func isFlagDir(dir cbo.ERCDir) bool {
+	switch dir {
+	case cbo.MultiOutputSinkFlag, cbo.NoConnectFlag, cbo.BusKeeperFlag, cbo.MultiLeaderFlag:
+		return true
+	default:
+		return false
+	}
+}
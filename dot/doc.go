@@ -0,0 +1,14 @@
+// Package dot produces Graphviz DOT representations of evaluated Cirbo
+// designs.
+//
+// The output is intended primarily as a debugging aid, allowing the
+// structure and connectivity of a design described in the textual Cirbo
+// language to be reviewed visually using the standard Graphviz tools, such
+// as:
+//
+//     dot -Tsvg design.dot > design.svg
+//
+// Two different views are available, selected via Options.Mode. The
+// hierarchy view shows which devices belong to which circuits, while the
+// connectivity view shows the nets that connect device terminals together.
+package dot
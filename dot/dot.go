@@ -0,0 +1,136 @@
+package dot
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// Mode selects which view of a design is written by Write.
+type Mode rune
+
+const (
+	// Hierarchy is a mode that shows each circuit instance as a cluster
+	// containing its devices and any nested circuit instances.
+	Hierarchy Mode = 'H'
+
+	// Connectivity is a mode that shows each device instance as a node
+	// with one port per terminal, with the nets between them shown as
+	// edges. Nets with more than two endpoints are shown as separate
+	// "hyperedge" nodes, labelled with the net's suggested name where
+	// available.
+	Connectivity Mode = 'C'
+)
+
+// Options customizes the output produced by Write.
+type Options struct {
+	Mode Mode
+
+	// ColorERC, if set, causes connections to be coloured by the ERC type of
+	// the endpoints they connect, and to have arrowheads reflecting the
+	// ERC direction of those endpoints. It is used only in Connectivity
+	// mode.
+	ColorERC bool
+}
+
+// Write writes a DOT graph describing the given root circuit instance and
+// everything nested within it to the given writer.
+//
+// The result is deterministic for a given design, so that it can be
+// committed to version control or compared between runs. An error is
+// returned only if writing to the given writer fails.
+func Write(w io.Writer, root *cbo.CircuitInstance, opts Options) error {
+	gw := &graphWriter{w: w}
+
+	switch opts.Mode {
+	case Hierarchy:
+		writeHierarchy(gw, root)
+	case Connectivity:
+		writeConnectivity(gw, root, opts.ColorERC)
+	default:
+		// should never happen if the caller is using the mode constants
+		panic(fmt.Errorf("unsupported DOT mode %q", opts.Mode))
+	}
+
+	return gw.err
+}
+
+// graphWriter is a helper for writing indented lines of DOT syntax that
+// retains the first write error, so that callers can write a whole graph
+// and then check for errors only once at the end.
+type graphWriter struct {
+	w      io.Writer
+	indent int
+	err    error
+}
+
+func (gw *graphWriter) Line(format string, args ...interface{}) {
+	if gw.err != nil {
+		return
+	}
+	line := strings.Repeat("\t", gw.indent) + fmt.Sprintf(format, args...) + "\n"
+	_, gw.err = io.WriteString(gw.w, line)
+}
+
+func (gw *graphWriter) Open(format string, args ...interface{}) {
+	gw.Line(format+" {", args...)
+	gw.indent++
+}
+
+func (gw *graphWriter) Close() {
+	gw.indent--
+	gw.Line("}")
+}
+
+// quote returns the given string as a DOT quoted string, escaping any
+// characters that would otherwise be misinterpreted.
+func quote(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, c := range s {
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		default:
+			buf.WriteRune(c)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// recordEscaper escapes the characters that have special meaning within
+// the label of a node with shape=record.
+var recordEscaper = strings.NewReplacer(
+	`{`, `\{`,
+	`}`, `\}`,
+	`|`, `\|`,
+	`<`, `\<`,
+	`>`, `\>`,
+)
+
+func instanceLabel(name, typeName string) string {
+	if typeName == "" || typeName == name {
+		return name
+	}
+	return fmt.Sprintf("%s\n%s", name, typeName)
+}
+
+func circuitName(inst *cbo.CircuitInstance) string {
+	if inst.Circuit == nil {
+		return ""
+	}
+	return inst.Circuit.Name
+}
+
+func deviceName(inst *cbo.DeviceInstance) string {
+	if inst.Device == nil {
+		return ""
+	}
+	return inst.Device.Name
+}
@@ -0,0 +1,167 @@
+package dot
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+func TestWriteHierarchy(t *testing.T) {
+	root := testDesign()
+
+	var buf bytes.Buffer
+	err := Write(&buf, root, Options{Mode: Hierarchy})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := buf.String()
+	want := `digraph "cirbo" {
+	node [shape=box];
+	subgraph "cluster_0" {
+		label="top\nTop";
+		"n0" [label="R1\nResistor"];
+		"n1" [label="U1\nRegulator"];
+		subgraph "cluster_1" {
+			label="led\nIndicator";
+			"n2" [label="led.D1\nLED"];
+		}
+	}
+}
+`
+	if got != want {
+		t.Errorf("wrong result\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteConnectivity(t *testing.T) {
+	root := testDesign()
+
+	t.Run("plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Write(&buf, root, Options{Mode: Connectivity})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		got := buf.String()
+		want := `digraph "cirbo" {
+	rankdir=LR;
+	node [shape=record];
+	subgraph "cluster_0" {
+		label="top\nTop";
+		"d0" [label="{R1\nResistor|{<t0>IN|<t1>OUT}}"];
+		"d1" [label="{U1\nRegulator|{<t0>VOUT|<t1>GND}}"];
+		subgraph "cluster_1" {
+			label="led\nIndicator";
+			"p2" [shape=cds, label="led.A"];
+			"d3" [label="{led.D1\nLED|{<t0>A|<t1>K}}"];
+		}
+	}
+	"net0" [shape=ellipse, label="GND"];
+	"d0":"t0" -> "net0" [dir=none];
+	"d1":"t1" -> "net0" [dir=none];
+	"d3":"t1" -> "net0" [dir=none];
+	"d0":"t1" -> "p2" [dir=none];
+	"p2" -> "d3":"t0" [dir=none];
+}
+`
+		if got != want {
+			t.Errorf("wrong result\ngot:\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("ERC colors", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Write(&buf, root, Options{Mode: Connectivity, ColorERC: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		got := buf.String()
+		wantLines := []string{
+			`"d1":"t1" -> "net0" [dir=forward, color="red"];`,
+			`"d0":"t0" -> "net0" [dir=none, color="gray40"];`,
+			`"d3":"t0" [dir=none, color="gray40"];`,
+		}
+		for _, want := range wantLines {
+			if !strings.Contains(got, want) {
+				t.Errorf("result does not contain %s\ngot:\n%s", want, got)
+			}
+		}
+	})
+}
+
+// testDesign returns a small design to use in tests: a regulator and a
+// resistor at the top level, and an LED inside a nested circuit, with the
+// negative sides of all of them joined on a ground net.
+func testDesign() *cbo.CircuitInstance {
+	passive := cbo.ERCMode{Type: cbo.Passive}
+	powerOut := cbo.ERCMode{Type: cbo.Power, Dir: cbo.Output, OutputType: cbo.PushPull}
+
+	r1 := testDevice("R1", "Resistor", []string{"IN", "OUT"}, passive, passive)
+	u1 := testDevice("U1", "Regulator", []string{"VOUT", "GND"}, powerOut, powerOut)
+	d1 := testDevice("D1", "LED", []string{"A", "K"}, passive, passive)
+
+	indicator := &cbo.Circuit{
+		Name: "Indicator",
+		Terminals: cbo.TerminalsDef{
+			All: map[string]cbo.Terminal{
+				"A": {Name: "A", ERC: passive},
+			},
+			Names: []string{"A"},
+		},
+	}
+	led := indicator.NewInstance("led", nil)
+	led.Devices = map[string]*cbo.DeviceInstance{"D1": d1}
+
+	root := &cbo.CircuitInstance{
+		Circuit: &cbo.Circuit{Name: "Top"},
+		Name:    "top",
+		Devices: map[string]*cbo.DeviceInstance{
+			"R1": r1,
+			"U1": u1,
+		},
+		Circuits: map[string]*cbo.CircuitInstance{
+			"led": led,
+		},
+	}
+
+	gnd := &cbo.Net{Endpoints: cbo.EndpointSet{}}
+	gnd.Connect(r1.Terminals["IN"].Outside[0])
+	gnd.Connect(u1.Terminals["GND"].Outside[0])
+	gnd.Connect(d1.Terminals["K"].Outside[0])
+
+	outer := &cbo.Net{Endpoints: cbo.EndpointSet{}}
+	outer.Connect(r1.Terminals["OUT"].Outside[0])
+	outer.Connect(led.Terminals["A"].Outside[0])
+
+	inner := &cbo.Net{Endpoints: cbo.EndpointSet{}}
+	inner.Connect(led.Terminals["A"].Inside[0])
+	inner.Connect(d1.Terminals["A"].Outside[0])
+
+	return root
+}
+
+func testDevice(name, typeName string, termNames []string, modes ...cbo.ERCMode) *cbo.DeviceInstance {
+	dev := &cbo.Device{
+		Name: typeName,
+		Terminals: cbo.TerminalsDef{
+			All:   map[string]cbo.Terminal{},
+			Names: termNames,
+		},
+	}
+	terms := map[string]*cbo.TerminalInstance{}
+	for i, tn := range termNames {
+		term := cbo.Terminal{Name: tn, ERC: modes[i]}
+		dev.Terminals.All[tn] = term
+		terms[tn] = term.NewInstance()
+	}
+	return &cbo.DeviceInstance{
+		Device:    dev,
+		Name:      name,
+		Terminals: terms,
+	}
+}
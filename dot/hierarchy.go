@@ -0,0 +1,46 @@
+package dot
+
+import (
+	"fmt"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+func writeHierarchy(gw *graphWriter, root *cbo.CircuitInstance) {
+	gw.Open("digraph %s", quote("cirbo"))
+	gw.Line("node [shape=box];")
+
+	nextCluster := 0
+	nextNode := 0
+
+	var writeCircuit func(path cbo.InstancePath, inst *cbo.CircuitInstance)
+	writeCircuit = func(path cbo.InstancePath, inst *cbo.CircuitInstance) {
+		gw.Open("subgraph %s", quote(fmt.Sprintf("cluster_%d", nextCluster)))
+		nextCluster++
+
+		name := path.String()
+		if name == "" {
+			name = inst.Name
+		}
+		gw.Line("label=%s;", quote(instanceLabel(name, circuitName(inst))))
+
+		for _, devName := range inst.DeviceNames() {
+			dev := inst.Devices[devName]
+			gw.Line(
+				"%s [label=%s];",
+				quote(fmt.Sprintf("n%d", nextNode)),
+				quote(instanceLabel(path.Child(devName).String(), deviceName(dev))),
+			)
+			nextNode++
+		}
+
+		for _, circName := range inst.CircuitNames() {
+			writeCircuit(path.Child(circName), inst.Circuits[circName])
+		}
+
+		gw.Close()
+	}
+	writeCircuit(nil, root)
+
+	gw.Close()
+}
@@ -2,14 +2,66 @@ package erc
 
 import (
 	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
 )
 
+// Checker applies the ERC rules with a particular configuration.
+//
+// The zero value of Checker is a valid checker using the default
+// configuration.
+type Checker struct {
+	// NoiseMargin is the minimum margin required between the logic levels
+	// produced by an output and the thresholds of the inputs it drives.
+	// If nil, the levels need only meet the thresholds exactly.
+	NoiseMargin *units.Quantity
+
+	// Severities overrides the default severity of rules, keyed by rule ID.
+	// Rules not present in the map, or mapped to DefaultSeverity, use the
+	// default severity from the rule catalogue.
+	Severities map[RuleID]Severity
+}
+
 // CheckNet applies the ERC rules to the given set of endpoints, assuming that
 // they all belong to the same net, and returns Error objects describing any
 // deviations from the rules.
 //
 // The result will be nil if no inconsistencies are detected.
+//
+// This is a shorthand for calling CheckNet on a zero-value Checker.
 func CheckNet(endpoints cbo.EndpointSet) Errors {
+	var c Checker
+	return c.CheckNet(endpoints)
+}
+
+// CheckNets is a helper wrapper around CheckNet that applies checks to many
+// nets in a single call.
+//
+// This is a shorthand for calling CheckNets on a zero-value Checker.
+func CheckNets(endpointSets map[*cbo.Net]cbo.EndpointSet) map[*cbo.Net]Errors {
+	var c Checker
+	return c.CheckNets(endpointSets)
+}
+
+// CheckNet applies the ERC rules to the given set of endpoints, assuming that
+// they all belong to the same net, and returns Error objects describing any
+// deviations from the rules.
+//
+// Violations of rules whose severity is SeverityOff are not returned, and
+// nor are violations of rules waived by any of the given endpoints. A waiver
+// without a justification is not honored, and is itself reported as a
+// violation of RuleUnjustifiedWaiver. Use
+// Checker.Severity to distinguish warnings from errors in the result.
+//
+// The result will be nil if no inconsistencies are detected.
+func (c *Checker) CheckNet(endpoints cbo.EndpointSet) Errors {
+	errs := c.checkNet(endpoints)
+	errs = append(errs, checkWaivers(nil, endpoints)...)
+	return c.filter(nil, endpoints, errs)
+}
+
+// checkNet is the main implementation of CheckNet, returning all of the
+// rule violations without regard to severity or waivers.
+func (c *Checker) checkNet(endpoints cbo.EndpointSet) Errors {
 	var errs Errors
 
 	if len(endpoints) == 1 {
@@ -23,6 +75,14 @@ func CheckNet(endpoints cbo.EndpointSet) Errors {
 
 	classes := classify(endpoints)
 
+	if len(classes.Passives) == len(endpoints) && len(endpoints) > 1 {
+		errs = append(errs, ErrorFloatingPassives{
+			Passives: classes.Passives,
+		})
+		// None of the other rules can apply to a net with only passives.
+		return errs
+	}
+
 	if len(classes.NoConnectFlags) > 0 {
 		if len(endpoints) > (len(classes.NoConnectFlags) + 1) {
 			errs = append(errs, ErrorNoConnectConnected{
@@ -37,13 +97,15 @@ func CheckNet(endpoints cbo.EndpointSet) Errors {
 
 	if len(classes.Outputs) > 0 && len(classes.Inputs) == 0 && len(classes.Bidis) == 0 && len(classes.MultiOutputFlags) == 0 {
 		errs = append(errs, ErrorNoInput{
-			Outputs: classes.Outputs,
+			Outputs:  classes.Outputs,
+			Passives: classes.Passives,
 		})
 	}
 
 	if len(classes.Inputs) > 0 && len(classes.Outputs) == 0 && len(classes.Bidis) == 0 {
 		errs = append(errs, ErrorNoOutput{
-			Inputs: classes.Inputs,
+			Inputs:   classes.Inputs,
+			Passives: classes.Passives,
 		})
 	}
 
@@ -72,19 +134,32 @@ func CheckNet(endpoints cbo.EndpointSet) Errors {
 		}
 	}
 
+	errs = append(errs, checkVoltages(endpoints)...)
+	errs = append(errs, c.checkLogicLevels(classes)...)
+	errs = append(errs, checkRoles(classes)...)
+
 	return errs
 }
 
 // CheckNets is a helper wrapper around CheckNet that applies checks to many
 // nets in a single call.
 //
+// In addition to the rules applied by CheckNet, CheckNets also checks that
+// nets driven by open collector, open emitter or tristate outputs have the
+// pull resistors or bus keepers they need. These rules depend on the
+// passthrough links between nets, which are not visible in a flattened
+// endpoint set alone.
+//
+// Rules waived on the net itself are filtered in the same way as rules
+// waived by its endpoints.
+//
 // The map returned will be nil if no errors are encountered at all, and will
 // have keys present only for nets that have errors.
-func CheckNets(endpointSets map[*cbo.Net]cbo.EndpointSet) map[*cbo.Net]Errors {
+func (c *Checker) CheckNets(endpointSets map[*cbo.Net]cbo.EndpointSet) map[*cbo.Net]Errors {
 	var ret map[*cbo.Net]Errors
 
 	for net, endpoints := range endpointSets {
-		errs := CheckNet(endpoints)
+		errs := c.filter(net, endpoints, c.checkNetFull(net, endpoints))
 		if errs != nil {
 			if ret == nil {
 				ret = make(map[*cbo.Net]Errors)
@@ -96,16 +171,31 @@ func CheckNets(endpointSets map[*cbo.Net]cbo.EndpointSet) map[*cbo.Net]Errors {
 	return ret
 }
 
+// checkNetFull returns all of the rule violations for the given net,
+// including those that depend on its passthrough links.
+func (c *Checker) checkNetFull(net *cbo.Net, endpoints cbo.EndpointSet) Errors {
+	errs := c.checkNet(endpoints)
+	errs = append(errs, checkPulls(net)...)
+	errs = append(errs, checkWaivers(net, endpoints)...)
+	return errs
+}
+
 type classifications struct {
-	Inputs  cbo.EndpointSet
-	Outputs cbo.EndpointSet
-	Bidis   cbo.EndpointSet
+	Inputs   cbo.EndpointSet
+	Outputs  cbo.EndpointSet
+	Bidis    cbo.EndpointSet
+	Passives cbo.EndpointSet
 
 	SignalOutputs cbo.EndpointSet
 	PowerInputs   cbo.EndpointSet
 
 	NoConnectFlags   cbo.EndpointSet
 	MultiOutputFlags cbo.EndpointSet
+	BusKeeperFlags   cbo.EndpointSet
+	MultiLeaderFlags cbo.EndpointSet
+
+	Leaders   cbo.EndpointSet
+	Followers cbo.EndpointSet
 }
 
 func classify(endpoints cbo.EndpointSet) classifications {
@@ -113,10 +203,15 @@ func classify(endpoints cbo.EndpointSet) classifications {
 	ret.Inputs = make(cbo.EndpointSet)
 	ret.Outputs = make(cbo.EndpointSet)
 	ret.Bidis = make(cbo.EndpointSet)
+	ret.Passives = make(cbo.EndpointSet)
 	ret.SignalOutputs = make(cbo.EndpointSet)
 	ret.PowerInputs = make(cbo.EndpointSet)
 	ret.NoConnectFlags = make(cbo.EndpointSet)
 	ret.MultiOutputFlags = make(cbo.EndpointSet)
+	ret.BusKeeperFlags = make(cbo.EndpointSet)
+	ret.MultiLeaderFlags = make(cbo.EndpointSet)
+	ret.Leaders = make(cbo.EndpointSet)
+	ret.Followers = make(cbo.EndpointSet)
 
 	for e := range endpoints {
 
@@ -127,10 +222,25 @@ func classify(endpoints cbo.EndpointSet) classifications {
 			ret.Outputs.Add(e)
 		case cbo.Bidirectional:
 			ret.Bidis.Add(e)
+		case cbo.Undirected:
+			if e.ERC.Type == cbo.Passive {
+				ret.Passives.Add(e)
+			}
 		case cbo.MultiOutputSinkFlag:
 			ret.MultiOutputFlags.Add(e)
 		case cbo.NoConnectFlag:
 			ret.NoConnectFlags.Add(e)
+		case cbo.BusKeeperFlag:
+			ret.BusKeeperFlags.Add(e)
+		case cbo.MultiLeaderFlag:
+			ret.MultiLeaderFlags.Add(e)
+		}
+
+		switch e.Role {
+		case cbo.Leader:
+			ret.Leaders.Add(e)
+		case cbo.Follower:
+			ret.Followers.Add(e)
 		}
 
 		switch e.ERC.Type {
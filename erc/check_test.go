@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
 	"github.com/davecgh/go-spew/spew"
 )
 
@@ -111,7 +112,8 @@ func TestCheckNet(t *testing.T) {
 				Endpoints: s,
 				Want: Errors{
 					ErrorNoInput{
-						Outputs: cbo.NewEndpointSet(out1, out2),
+						Outputs:  cbo.NewEndpointSet(out1, out2),
+						Passives: cbo.NewEndpointSet(),
 					},
 					ErrorOutputConflict{
 						Outputs: cbo.NewEndpointSet(out1, out2),
@@ -143,7 +145,8 @@ func TestCheckNet(t *testing.T) {
 				Endpoints: s,
 				Want: Errors{
 					ErrorNoInput{
-						Outputs: cbo.NewEndpointSet(out1, out2),
+						Outputs:  cbo.NewEndpointSet(out1, out2),
+						Passives: cbo.NewEndpointSet(),
 					},
 				},
 			}
@@ -170,7 +173,8 @@ func TestCheckNet(t *testing.T) {
 				Endpoints: s,
 				Want: Errors{
 					ErrorNoOutput{
-						Inputs: cbo.NewEndpointSet(in1, in2),
+						Inputs:   cbo.NewEndpointSet(in1, in2),
+						Passives: cbo.NewEndpointSet(),
 					},
 				},
 			}
@@ -363,6 +367,355 @@ func TestCheckNet(t *testing.T) {
 				},
 			}
 		},
+		"5V signal output driving 3.3V-only input": func() testCase {
+			s := make(cbo.EndpointSet)
+			out := &cbo.Endpoint{
+				Name: "OUT",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Signal,
+					Dir:        cbo.Output,
+					OutputType: cbo.PushPull,
+				},
+				Voltage: &cbo.VoltageSpec{
+					VOL: volts(0.4),
+					VOH: volts(4.5),
+				},
+			}
+			in := &cbo.Endpoint{
+				Name: "IN",
+				ERC: cbo.ERCMode{
+					Type: cbo.Signal,
+					Dir:  cbo.Input,
+				},
+				Voltage: &cbo.VoltageSpec{
+					AbsMax: volts(3.6),
+				},
+			}
+			s.Add(out)
+			s.Add(in)
+			return testCase{
+				Endpoints: s,
+				Want: Errors{
+					ErrorOverVoltage{
+						Drivers: cbo.NewEndpointSet(out),
+						Driving: cbo.NewEndpointSet(in),
+						Max:     *volts(4.5),
+					},
+				},
+			}
+		},
+		"3.3V supply within input range": func() testCase {
+			s := make(cbo.EndpointSet)
+			s.Add(&cbo.Endpoint{
+				Name: "VOUT",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Power,
+					Dir:        cbo.Output,
+					OutputType: cbo.PushPull,
+				},
+				Voltage: &cbo.VoltageSpec{
+					SupplyMin: volts(3.25),
+					SupplyMax: volts(3.35),
+				},
+			})
+			s.Add(&cbo.Endpoint{
+				Name: "VDD",
+				ERC: cbo.ERCMode{
+					Type: cbo.Power,
+					Dir:  cbo.Input,
+				},
+				Voltage: &cbo.VoltageSpec{
+					SupplyMin: volts(1.8),
+					SupplyMax: volts(3.6),
+					AbsMax:    volts(4),
+				},
+			})
+			return testCase{
+				Endpoints: s,
+				Want:      Errors(nil),
+			}
+		},
+		"5V supply outside input range": func() testCase {
+			s := make(cbo.EndpointSet)
+			out := &cbo.Endpoint{
+				Name: "VOUT",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Power,
+					Dir:        cbo.Output,
+					OutputType: cbo.PushPull,
+				},
+				Voltage: &cbo.VoltageSpec{
+					SupplyMin: volts(5),
+				},
+			}
+			in := &cbo.Endpoint{
+				Name: "VDD",
+				ERC: cbo.ERCMode{
+					Type: cbo.Power,
+					Dir:  cbo.Input,
+				},
+				Voltage: &cbo.VoltageSpec{
+					SupplyMin: volts(1.8),
+					SupplyMax: volts(3.6),
+				},
+			}
+			s.Add(out)
+			s.Add(in)
+			return testCase{
+				Endpoints: s,
+				Want: Errors{
+					ErrorSupplyVoltage{
+						Supplies: cbo.NewEndpointSet(out),
+						Loads:    cbo.NewEndpointSet(in),
+						Min:      *volts(5),
+						Max:      *volts(5),
+					},
+				},
+			}
+		},
+		"3.3V output driving 5V CMOS input": func() testCase {
+			s := make(cbo.EndpointSet)
+			out := &cbo.Endpoint{
+				Name: "OUT",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Signal,
+					Dir:        cbo.Output,
+					OutputType: cbo.PushPull,
+				},
+				Voltage: &cbo.VoltageSpec{
+					VOL: volts(0.4),
+					VOH: volts(2.9),
+				},
+			}
+			in := &cbo.Endpoint{
+				Name: "IN",
+				ERC: cbo.ERCMode{
+					Type: cbo.Signal,
+					Dir:  cbo.Input,
+				},
+				Voltage: &cbo.VoltageSpec{
+					VIL: volts(1.5),
+					VIH: volts(3.5),
+				},
+			}
+			s.Add(out)
+			s.Add(in)
+			return testCase{
+				Endpoints: s,
+				Want: Errors{
+					ErrorLogicLevelMismatch{
+						Driver:   out,
+						Receiver: in,
+						High:     true,
+					},
+				},
+			}
+		},
+		"open collector output with high threshold": func() testCase {
+			s := make(cbo.EndpointSet)
+			s.Add(&cbo.Endpoint{
+				Name: "OUT",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Signal,
+					Dir:        cbo.Output,
+					OutputType: cbo.OpenCollector,
+				},
+				Voltage: &cbo.VoltageSpec{
+					VOL: volts(0.4),
+				},
+			})
+			s.Add(&cbo.Endpoint{
+				Name: "IN",
+				ERC: cbo.ERCMode{
+					Type: cbo.Signal,
+					Dir:  cbo.Input,
+				},
+				Voltage: &cbo.VoltageSpec{
+					VIL: volts(1.5),
+					VIH: volts(3.5),
+				},
+			})
+			return testCase{
+				Endpoints: s,
+				Want:      Errors(nil),
+			}
+		},
+		"passives only": func() testCase {
+			s := make(cbo.EndpointSet)
+			r1 := &cbo.Endpoint{
+				Name: "R1",
+				ERC: cbo.ERCMode{
+					Type: cbo.Passive,
+				},
+			}
+			c1 := &cbo.Endpoint{
+				Name: "C1",
+				ERC: cbo.ERCMode{
+					Type: cbo.Passive,
+				},
+			}
+			s.Add(r1)
+			s.Add(c1)
+			return testCase{
+				Endpoints: s,
+				Want: Errors{
+					ErrorFloatingPassives{
+						Passives: cbo.NewEndpointSet(r1, c1),
+					},
+				},
+			}
+		},
+		"input with only passives": func() testCase {
+			s := make(cbo.EndpointSet)
+			in := &cbo.Endpoint{
+				Name: "IN",
+				ERC: cbo.ERCMode{
+					Type: cbo.Signal,
+					Dir:  cbo.Input,
+				},
+			}
+			r1 := &cbo.Endpoint{
+				Name: "R1",
+				ERC: cbo.ERCMode{
+					Type: cbo.Passive,
+				},
+			}
+			s.Add(in)
+			s.Add(r1)
+			return testCase{
+				Endpoints: s,
+				Want: Errors{
+					ErrorNoOutput{
+						Inputs:   cbo.NewEndpointSet(in),
+						Passives: cbo.NewEndpointSet(r1),
+					},
+				},
+			}
+		},
+		"two leaders on SPI bus": func() testCase {
+			s := make(cbo.EndpointSet)
+			mosi1 := &cbo.Endpoint{
+				Name: "MOSI1",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Signal,
+					Dir:        cbo.Output,
+					OutputType: cbo.Tristate,
+				},
+				Role: cbo.Leader,
+			}
+			mosi2 := &cbo.Endpoint{
+				Name: "MOSI2",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Signal,
+					Dir:        cbo.Output,
+					OutputType: cbo.Tristate,
+				},
+				Role: cbo.Leader,
+			}
+			s.Add(mosi1)
+			s.Add(mosi2)
+			s.Add(&cbo.Endpoint{
+				Name: "SDI",
+				ERC: cbo.ERCMode{
+					Type: cbo.Signal,
+					Dir:  cbo.Input,
+				},
+				Role: cbo.Follower,
+			})
+			return testCase{
+				Endpoints: s,
+				Want: Errors{
+					ErrorMultipleLeaders{
+						Leaders: cbo.NewEndpointSet(mosi1, mosi2),
+					},
+				},
+			}
+		},
+		"multi-master I2C bus": func() testCase {
+			s := make(cbo.EndpointSet)
+			for _, name := range []string{"SDA1", "SDA2"} {
+				s.Add(&cbo.Endpoint{
+					Name: name,
+					ERC: cbo.ERCMode{
+						Type:       cbo.Signal,
+						Dir:        cbo.Bidirectional,
+						OutputType: cbo.OpenCollector,
+					},
+					Role: cbo.Leader,
+				})
+			}
+			s.Add(&cbo.Endpoint{
+				Name: "MULTI",
+				ERC: cbo.ERCMode{
+					Type: cbo.Passive,
+					Dir:  cbo.MultiLeaderFlag,
+				},
+			})
+			return testCase{
+				Endpoints: s,
+				Want:      Errors(nil),
+			}
+		},
+		"bidirectional without role": func() testCase {
+			s := make(cbo.EndpointSet)
+			sda1 := &cbo.Endpoint{
+				Name: "SDA1",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Signal,
+					Dir:        cbo.Bidirectional,
+					OutputType: cbo.OpenCollector,
+				},
+				Role: cbo.Leader,
+			}
+			sda2 := &cbo.Endpoint{
+				Name: "SDA2",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Signal,
+					Dir:        cbo.Bidirectional,
+					OutputType: cbo.OpenCollector,
+				},
+			}
+			s.Add(sda1)
+			s.Add(sda2)
+			return testCase{
+				Endpoints: s,
+				Want: Errors{
+					ErrorBidirectionalNoRole{
+						Endpoints: cbo.NewEndpointSet(sda2),
+					},
+				},
+			}
+		},
+		"followers only": func() testCase {
+			s := make(cbo.EndpointSet)
+			out := &cbo.Endpoint{
+				Name: "MISO",
+				ERC: cbo.ERCMode{
+					Type:       cbo.Signal,
+					Dir:        cbo.Output,
+					OutputType: cbo.Tristate,
+				},
+				Role: cbo.Follower,
+			}
+			in := &cbo.Endpoint{
+				Name: "SDI",
+				ERC: cbo.ERCMode{
+					Type: cbo.Signal,
+					Dir:  cbo.Input,
+				},
+				Role: cbo.Follower,
+			}
+			s.Add(out)
+			s.Add(in)
+			return testCase{
+				Endpoints: s,
+				Want: Errors{
+					ErrorNoLeader{
+						Followers: cbo.NewEndpointSet(out, in),
+					},
+				},
+			}
+		},
 	}
 
 	spewer := spew.NewDefaultConfig()
@@ -379,6 +732,50 @@ func TestCheckNet(t *testing.T) {
 	}
 }
 
+func TestCheckerNoiseMargin(t *testing.T) {
+	out := &cbo.Endpoint{
+		Name: "OUT",
+		ERC: cbo.ERCMode{
+			Type:       cbo.Signal,
+			Dir:        cbo.Output,
+			OutputType: cbo.PushPull,
+		},
+		Voltage: &cbo.VoltageSpec{
+			VOL: volts(0.2),
+			VOH: volts(2.4),
+		},
+	}
+	in := &cbo.Endpoint{
+		Name: "IN",
+		ERC: cbo.ERCMode{
+			Type: cbo.Signal,
+			Dir:  cbo.Input,
+		},
+		Voltage: &cbo.VoltageSpec{
+			VIL: volts(0.8),
+			VIH: volts(2.0),
+		},
+	}
+	s := cbo.NewEndpointSet(out, in)
+
+	if errs := CheckNet(s); errs != nil {
+		t.Errorf("unexpected errors with no margin: %s", errs)
+	}
+
+	c := &Checker{NoiseMargin: volts(0.5)}
+	got := c.CheckNet(s)
+	want := Errors{
+		ErrorLogicLevelMismatch{
+			Driver:   out,
+			Receiver: in,
+			High:     true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result with margin\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
 func TestClassify(t *testing.T) {
 	s := make(cbo.EndpointSet)
 
@@ -433,6 +830,12 @@ func TestClassify(t *testing.T) {
 			Dir:  cbo.NoConnectFlag,
 		},
 	})
+	s.Add(&cbo.Endpoint{
+		Name: "R1",
+		ERC: cbo.ERCMode{
+			Type: cbo.Passive,
+		},
+	})
 	s.Add(&cbo.Endpoint{
 		Name: "MOF",
 		ERC: cbo.ERCMode{
@@ -452,6 +855,9 @@ func TestClassify(t *testing.T) {
 	if got, want := classes.Bidis.Names(), []string{"SDA"}; !reflect.DeepEqual(got, want) {
 		t.Errorf("wrong bidis\ngot:  %#v\nwant: %#v", got, want)
 	}
+	if got, want := classes.Passives.Names(), []string{"R1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong passives\ngot:  %#v\nwant: %#v", got, want)
+	}
 	if got, want := classes.SignalOutputs.Names(), []string{"MISO", "SDA"}; !reflect.DeepEqual(got, want) {
 		t.Errorf("wrong signal outputs\ngot:  %#v\nwant: %#v", got, want)
 	}
@@ -465,3 +871,8 @@ func TestClassify(t *testing.T) {
 		t.Errorf("wrong multi-output flags\ngot:  %#v\nwant: %#v", got, want)
 	}
 }
+
+func volts(v float64) *units.Quantity {
+	q := units.MakeQuantityFloat(v, units.Volt)
+	return &q
+}
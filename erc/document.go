@@ -0,0 +1,316 @@
+package erc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// DocumentVersion is the version of the schema of ReportDocument. It will be
+// incremented if a future release changes the schema in a way that is not
+// backward-compatible.
+const DocumentVersion = 1
+
+// ReportDocument is a serializable representation of the findings from
+// Checker.Report, for consumption by other software and for rendering into
+// human-readable reports.
+//
+// The JSON serialization of this type, produced by WriteJSON, is a stable
+// interface whose schema is versioned by DocumentVersion.
+type ReportDocument struct {
+	Version  int               `json:"version"`
+	Summary  DocumentSummary   `json:"summary"`
+	Findings []DocumentFinding `json:"findings"`
+}
+
+// DocumentSummary counts the findings in a ReportDocument.
+//
+// Waived findings are counted only in Waived, regardless of their severity.
+type DocumentSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Waived   int `json:"waived"`
+}
+
+// DocumentFinding is the serializable representation of a Finding.
+type DocumentFinding struct {
+	Rule     RuleID `json:"rule"`
+	Severity string `json:"severity"`
+	Net      string `json:"net"`
+	Message  string `json:"message"`
+
+	// Endpoints are the endpoints involved in the finding, each labelled
+	// with the group it belongs to. The groups depend on the rule; for
+	// example, a no-output finding has "inputs" and "passives" groups.
+	Endpoints []DocumentEndpoint `json:"endpoints"`
+
+	// Details are any other rule-specific values, such as the voltage
+	// range of a supply-voltage finding.
+	Details map[string]interface{} `json:"details,omitempty"`
+
+	Waiver *DocumentWaiver `json:"waiver,omitempty"`
+}
+
+// DocumentEndpoint is the serializable representation of an endpoint that
+// is involved in a finding.
+type DocumentEndpoint struct {
+	Group      string `json:"group"`
+	Path       string `json:"path"`
+	Type       string `json:"type"`
+	Dir        string `json:"dir"`
+	OutputType string `json:"output_type,omitempty"`
+	Role       string `json:"role,omitempty"`
+}
+
+// DocumentWaiver is the serializable representation of a waiver that covers
+// a finding.
+type DocumentWaiver struct {
+	Justification string `json:"justification"`
+}
+
+// NewReportDocument builds a ReportDocument from the given findings, which
+// are included in the order given.
+//
+// The given paths, as returned from cbo.CircuitInstance.EndpointPaths, are
+// used to identify the endpoints in each finding. Any endpoint not present
+// in the map is identified by its name alone.
+//
+// Each net is identified by its suggested name where available, or
+// otherwise by a name derived from the path of one of its endpoints.
+func NewReportDocument(findings []Finding, paths map[*cbo.Endpoint]cbo.InstancePath) *ReportDocument {
+	namer := &documentNamer{
+		paths: paths,
+		nets:  map[*cbo.Net]string{},
+	}
+
+	ret := &ReportDocument{
+		Version:  DocumentVersion,
+		Findings: make([]DocumentFinding, 0, len(findings)),
+	}
+
+	for _, f := range findings {
+		switch {
+		case f.Waived():
+			ret.Summary.Waived++
+		case f.Severity == SeverityError:
+			ret.Summary.Errors++
+		case f.Severity == SeverityWarning:
+			ret.Summary.Warnings++
+		}
+
+		groups, details := errorParts(f.Error)
+		df := DocumentFinding{
+			Rule:      f.Error.Rule(),
+			Severity:  documentSeverity(f.Severity),
+			Net:       namer.NetName(f.Net),
+			Message:   f.Error.Error(),
+			Endpoints: []DocumentEndpoint{},
+			Details:   details,
+		}
+		for _, group := range groups {
+			for _, ep := range namer.Sorted(group.Endpoints) {
+				df.Endpoints = append(df.Endpoints, DocumentEndpoint{
+					Group:      group.Name,
+					Path:       namer.EndpointPath(ep),
+					Type:       ep.ERC.Type.String(),
+					Dir:        ep.ERC.Dir.String(),
+					OutputType: documentOutputType(ep.ERC.OutputType),
+					Role:       documentRole(ep.Role),
+				})
+			}
+		}
+		if f.Waiver != nil {
+			df.Waiver = &DocumentWaiver{
+				Justification: f.Waiver.Justification,
+			}
+		}
+		ret.Findings = append(ret.Findings, df)
+	}
+
+	return ret
+}
+
+// WriteJSON writes the receiver to the given writer as an indented JSON
+// document.
+func (d *ReportDocument) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// Fatal returns true if the document includes any unwaived errors.
+func (d *ReportDocument) Fatal() bool {
+	return d.Summary.Errors > 0
+}
+
+// endpointGroup is a named set of endpoints involved in an error.
+type endpointGroup struct {
+	Name      string
+	Endpoints cbo.EndpointSet
+}
+
+// errorParts decomposes the given error into its groups of endpoints and
+// any other details, for serialization.
+func errorParts(err Error) ([]endpointGroup, map[string]interface{}) {
+	switch e := err.(type) {
+	case ErrorNoOutput:
+		return []endpointGroup{
+			{"inputs", e.Inputs},
+			{"passives", e.Passives},
+		}, nil
+	case ErrorNoInput:
+		return []endpointGroup{
+			{"outputs", e.Outputs},
+			{"passives", e.Passives},
+		}, nil
+	case ErrorSignalAsPower:
+		return []endpointGroup{
+			{"drivers", e.Drivers},
+			{"driving", e.Driving},
+		}, nil
+	case ErrorOutputConflict:
+		return []endpointGroup{
+			{"outputs", e.Outputs},
+		}, nil
+	case ErrorUnconnected:
+		return []endpointGroup{
+			{"endpoint", cbo.NewEndpointSet(e.Endpoint)},
+		}, nil
+	case ErrorNoConnectConnected:
+		return []endpointGroup{
+			{"endpoints", e.Endpoints},
+			{"flags", e.Flags},
+		}, nil
+	case ErrorSupplyVoltage:
+		return []endpointGroup{
+				{"supplies", e.Supplies},
+				{"loads", e.Loads},
+			}, map[string]interface{}{
+				"min": e.Min.String(),
+				"max": e.Max.String(),
+			}
+	case ErrorOverVoltage:
+		return []endpointGroup{
+				{"drivers", e.Drivers},
+				{"driving", e.Driving},
+			}, map[string]interface{}{
+				"max": e.Max.String(),
+			}
+	case ErrorLogicLevelMismatch:
+		return []endpointGroup{
+				{"driver", cbo.NewEndpointSet(e.Driver)},
+				{"receiver", cbo.NewEndpointSet(e.Receiver)},
+			}, map[string]interface{}{
+				"high": e.High,
+				"low":  e.Low,
+			}
+	case ErrorNoPullUp:
+		return []endpointGroup{
+			{"outputs", e.Outputs},
+		}, nil
+	case ErrorNoPullDown:
+		return []endpointGroup{
+			{"outputs", e.Outputs},
+		}, nil
+	case ErrorFloatingBus:
+		return []endpointGroup{
+			{"outputs", e.Outputs},
+		}, nil
+	case ErrorFloatingPassives:
+		return []endpointGroup{
+			{"passives", e.Passives},
+		}, nil
+	case ErrorBidirectionalNoRole:
+		return []endpointGroup{
+			{"endpoints", e.Endpoints},
+		}, nil
+	case ErrorMultipleLeaders:
+		return []endpointGroup{
+			{"leaders", e.Leaders},
+		}, nil
+	case ErrorNoLeader:
+		return []endpointGroup{
+			{"followers", e.Followers},
+		}, nil
+	default:
+		// should never happen, since all of the error types are above
+		panic(fmt.Errorf("unsupported ERC error type %T", err))
+	}
+}
+
+func documentSeverity(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		// should never happen, since findings are only produced for rules
+		// that are not turned off.
+		panic(fmt.Errorf("unsupported severity %s", s))
+	}
+}
+
+func documentOutputType(t cbo.ERCOutputType) string {
+	if t == cbo.NoOutput {
+		return ""
+	}
+	return t.String()
+}
+
+func documentRole(r cbo.TerminalRole) string {
+	if r == cbo.NoRole {
+		return ""
+	}
+	return r.String()
+}
+
+// documentNamer assigns the names used to identify nets and endpoints in a
+// ReportDocument.
+type documentNamer struct {
+	paths map[*cbo.Endpoint]cbo.InstancePath
+	nets  map[*cbo.Net]string
+}
+
+func (n *documentNamer) EndpointPath(ep *cbo.Endpoint) string {
+	if path, exists := n.paths[ep]; exists {
+		return path.String()
+	}
+	return ep.Name
+}
+
+// Sorted returns the endpoints in the given set ordered by path, so that
+// the document is deterministic for a given design.
+func (n *documentNamer) Sorted(eps cbo.EndpointSet) []*cbo.Endpoint {
+	ret := eps.List()
+	sort.Slice(ret, func(i, j int) bool {
+		return n.EndpointPath(ret[i]) < n.EndpointPath(ret[j])
+	})
+	return ret
+}
+
+// NetName returns a name for the given net. Nets without a suggested name
+// are named after the lexically-first path among their endpoints, which is
+// stable for a given design but not necessarily unique within it.
+func (n *documentNamer) NetName(net *cbo.Net) string {
+	if net == nil {
+		return ""
+	}
+	if name, exists := n.nets[net]; exists {
+		return name
+	}
+
+	name := net.SuggestedName()
+	if name == "" {
+		eps := n.Sorted(net.Endpoints)
+		if len(eps) > 0 {
+			name = fmt.Sprintf("Net-(%s)", n.EndpointPath(eps[0]))
+		}
+	}
+
+	n.nets[net] = name
+	return name
+}
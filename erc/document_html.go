@@ -0,0 +1,85 @@
+package erc
+
+import (
+	"html/template"
+	"io"
+	"sort"
+)
+
+// WriteHTML writes a static HTML page summarizing the receiver to the given
+// writer, with the findings grouped by net.
+//
+// Nets are listed in lexical order by name, and the findings for each net
+// are listed in the order they appear in the document. The page has no
+// external dependencies, so it can be published as a build artifact.
+func (d *ReportDocument) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, htmlReport{
+		ReportDocument: d,
+		Nets:           d.netGroups(),
+	})
+}
+
+// htmlReport is the data passed to htmlTemplate.
+type htmlReport struct {
+	*ReportDocument
+	Nets []htmlNet
+}
+
+type htmlNet struct {
+	Name     string
+	Findings []DocumentFinding
+}
+
+func (d *ReportDocument) netGroups() []htmlNet {
+	var ret []htmlNet
+	index := map[string]int{}
+	for _, f := range d.Findings {
+		i, exists := index[f.Net]
+		if !exists {
+			i = len(ret)
+			index[f.Net] = i
+			ret = append(ret, htmlNet{Name: f.Net})
+		}
+		ret[i].Findings = append(ret[i].Findings, f)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Electrical Rules Check</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+.error { color: #b00; }
+.warning { color: #a60; }
+.waived { color: #888; }
+ul { margin: 0; padding-left: 1.2em; }
+</style>
+</head>
+<body>
+<h1>Electrical Rules Check</h1>
+<p>{{.Summary.Errors}} error(s), {{.Summary.Warnings}} warning(s), {{.Summary.Waived}} waived.</p>
+{{- range .Nets}}
+<h2>{{if .Name}}{{.Name}}{{else}}(unnamed net){{end}}</h2>
+<table>
+<tr><th>Severity</th><th>Rule</th><th>Message</th><th>Endpoints</th></tr>
+{{- range .Findings}}
+<tr class="{{if .Waiver}}waived{{else}}{{.Severity}}{{end}}">
+<td>{{.Severity}}{{if .Waiver}} (waived){{end}}</td>
+<td>{{.Rule}}</td>
+<td>{{.Message}}{{if .Waiver}}<br>Waived: {{.Waiver.Justification}}{{end}}</td>
+<td><ul>{{range .Endpoints}}<li>{{.Group}}: {{.Path}} ({{.Type}} {{.Dir}}{{if .OutputType}} {{.OutputType}}{{end}}{{if .Role}} {{.Role}}{{end}})</li>{{end}}</ul></td>
+</tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
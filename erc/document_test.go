@@ -0,0 +1,150 @@
+package erc
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+func TestReportDocument(t *testing.T) {
+	inputs := testNet()
+	in1 := &cbo.Endpoint{
+		Name: "A",
+		ERC:  cbo.ERCMode{Type: cbo.Signal, Dir: cbo.Input},
+	}
+	in2 := &cbo.Endpoint{
+		Name: "B",
+		ERC:  cbo.ERCMode{Type: cbo.Signal, Dir: cbo.Input},
+		Role: cbo.Leader,
+	}
+	inputs.Connect(in1)
+	inputs.Connect(in2)
+
+	gnd := testNet()
+	out := &cbo.Endpoint{
+		Name: "GND",
+		ERC:  cbo.ERCMode{Type: cbo.Power, Dir: cbo.Output, OutputType: cbo.PushPull},
+	}
+	gnd.Connect(out)
+	gnd.Waivers = []cbo.Waiver{
+		{
+			Rule:          string(RuleUnconnected),
+			Justification: "<test> fixture",
+		},
+	}
+
+	paths := map[*cbo.Endpoint]cbo.InstancePath{
+		in1: cbo.InstancePath{"sub", "U2", "A"},
+		in2: cbo.InstancePath{"U1", "B"},
+	}
+
+	c := Checker{
+		Severities: map[RuleID]Severity{
+			RuleNoOutput: SeverityWarning,
+		},
+	}
+	doc := NewReportDocument(c.Report(map[*cbo.Net]cbo.EndpointSet{
+		inputs: inputs.Endpoints,
+		gnd:    gnd.Endpoints,
+	}), paths)
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := doc.WriteJSON(&buf); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		var got map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %s\n%s", err, buf.String())
+		}
+
+		want := map[string]interface{}{
+			"version": float64(DocumentVersion),
+			"summary": map[string]interface{}{
+				"errors":   float64(0),
+				"warnings": float64(1),
+				"waived":   float64(1),
+			},
+			"findings": []interface{}{
+				map[string]interface{}{
+					"rule":     "unconnected",
+					"severity": "error",
+					"net":      "GND",
+					"message":  "GND is not connected to anything",
+					"endpoints": []interface{}{
+						map[string]interface{}{
+							"group":       "endpoint",
+							"path":        "GND",
+							"type":        "Power",
+							"dir":         "Output",
+							"output_type": "PushPull",
+						},
+					},
+					"waiver": map[string]interface{}{
+						"justification": "<test> fixture",
+					},
+				},
+				map[string]interface{}{
+					"rule":     "no-output",
+					"severity": "warning",
+					"net":      "Net-(U1.B)",
+					"message":  "Input(s) A, B are not driven by any output",
+					"endpoints": []interface{}{
+						map[string]interface{}{
+							"group": "inputs",
+							"path":  "U1.B",
+							"type":  "Signal",
+							"dir":   "Input",
+							"role":  "Leader",
+						},
+						map[string]interface{}{
+							"group": "inputs",
+							"path":  "sub.U2.A",
+							"type":  "Signal",
+							"dir":   "Input",
+						},
+					},
+				},
+			},
+		}
+
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		wantJSON, _ := json.MarshalIndent(want, "", "  ")
+		if !bytes.Equal(gotJSON, wantJSON) {
+			t.Errorf("wrong result\ngot:\n%s\nwant:\n%s", gotJSON, wantJSON)
+		}
+
+		if doc.Fatal() {
+			t.Errorf("document is fatal with only warnings and waived errors")
+		}
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := doc.WriteHTML(&buf); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got := buf.String()
+
+		for _, want := range []string{
+			"0 error(s), 1 warning(s), 1 waived.",
+			"<h2>GND</h2>",
+			"<h2>Net-(U1.B)</h2>",
+			"Waived: &lt;test&gt; fixture",
+			"<li>inputs: sub.U2.A (Signal Input)</li>",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("result does not contain %q\n%s", want, got)
+			}
+		}
+
+		// Nets are listed by name, so GND comes first.
+		if strings.Index(got, "<h2>GND</h2>") > strings.Index(got, "<h2>Net-(U1.B)</h2>") {
+			t.Errorf("nets are not in lexical order")
+		}
+	})
+}
@@ -5,12 +5,17 @@ import (
 	"strings"
 
 	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
 )
 
 // Error represents an error encountered during an electrical rules check.
 type Error interface {
 	error
 	errorSigil() isError
+
+	// Rule returns the identifier of the rule that the error reports a
+	// violation of.
+	Rule() RuleID
 }
 
 type isError struct {
@@ -105,7 +110,134 @@ type ErrorNoConnectConnected struct {
 	Flags     cbo.EndpointSet
 }
 
+// ErrorSupplyVoltage is an error returned when the voltage produced by the
+// power outputs on a net falls outside of the supply range accepted by one
+// or more of the power inputs on the same net.
+//
+// Min and Max give the range of voltage that the power outputs may produce.
+type ErrorSupplyVoltage struct {
+	isError
+	Supplies cbo.EndpointSet
+	Loads    cbo.EndpointSet
+	Min      units.Quantity
+	Max      units.Quantity
+}
+
+// ErrorOverVoltage is an error returned when the outputs on a net may drive
+// it to a voltage that exceeds the absolute maximum rating of one or more
+// other endpoints on the same net. This is most commonly caused by
+// connecting devices from different voltage domains without a level
+// shifter.
+//
+// Max is the highest voltage that the drivers may produce.
+type ErrorOverVoltage struct {
+	isError
+	Drivers cbo.EndpointSet
+	Driving cbo.EndpointSet
+	Max     units.Quantity
+}
+
+// ErrorLogicLevelMismatch is an error returned when an output's logic
+// levels do not reliably cross the logic thresholds of an input it drives,
+// including any noise margin required by the checker.
+//
+// High is set if the output's VOH is not high enough for the input's VIH,
+// and Low is set if the output's VOL is not low enough for the input's VIL.
+type ErrorLogicLevelMismatch struct {
+	isError
+	Driver   *cbo.Endpoint
+	Receiver *cbo.Endpoint
+	High     bool
+	Low      bool
+}
+
+// ErrorNoPullUp is an error returned when a net has open collector (or
+// open drain) outputs but no pull-up resistor to a power net, and so will
+// never be driven high.
+type ErrorNoPullUp struct {
+	isError
+	Outputs cbo.EndpointSet
+}
+
+// ErrorNoPullDown is an error returned when a net has open emitter (or
+// open source) outputs but no pull-down resistor to ground, and so will
+// never be driven low.
+type ErrorNoPullDown struct {
+	isError
+	Outputs cbo.EndpointSet
+}
+
+// ErrorFloatingBus is an error returned when a net is driven only by
+// tristate outputs and has neither a pull resistor nor a bus keeper, and so
+// will float when none of the outputs are enabled.
+//
+// This can be overridden with an ERC-only component that has an endpoint of
+// direction BusKeeperFlag, if the bus is held by some other means.
+type ErrorFloatingBus struct {
+	isError
+	Outputs cbo.EndpointSet
+}
+
+// ErrorFloatingPassives is an error returned when a net has only passive
+// endpoints, and is not connected (directly or through passthrough
+// endpoints) to anything that could drive it. This usually indicates a
+// missing connection to a group of passive components.
+//
+// This can be overridden with an ERC-only component that has a placeholder
+// output endpoint.
+type ErrorFloatingPassives struct {
+	isError
+	Passives cbo.EndpointSet
+}
+
+// ErrorBidirectionalNoRole is an error returned when a net has
+// bidirectional endpoints that have no leader/follower role, which is
+// required for all bidirectional terminals.
+type ErrorBidirectionalNoRole struct {
+	isError
+	Endpoints cbo.EndpointSet
+}
+
+// ErrorMultipleLeaders is an error returned when a net has more than one
+// Leader endpoint, such as when two controllers are both wired as the
+// master of the same bus.
+//
+// An endpoint of direction MultiLeaderFlag can be connected to the net to
+// override this error where multiple leaders are intended, such as on a
+// multi-master I2C bus.
+type ErrorMultipleLeaders struct {
+	isError
+	Leaders cbo.EndpointSet
+}
+
+// ErrorNoLeader is an error returned when a net has Follower endpoints but
+// no Leader endpoint to initiate communication with them.
+type ErrorNoLeader struct {
+	isError
+	Followers cbo.EndpointSet
+}
+
+// ErrorUnjustifiedWaiver is an error returned when a net or one of its
+// endpoints has a waiver without a justification. Such a waiver is not
+// honored, so the violation it was intended to waive is also reported.
+//
+// Net is set for a waiver on the net itself, and Endpoint for a waiver on
+// one of its endpoints.
+type ErrorUnjustifiedWaiver struct {
+	isError
+	Waived   RuleID
+	Net      *cbo.Net
+	Endpoint *cbo.Endpoint
+}
+
 func (e ErrorNoOutput) Error() string {
+	if len(e.Passives) > 0 {
+		return fmt.Sprintf(
+			"Input(s) %s are not driven by any output, and are connected only to passive(s) %s",
+			strings.Join(e.Inputs.Names(), ", "),
+			strings.Join(e.Passives.Names(), ", "),
+		)
+	}
 	return fmt.Sprintf(
 		"Input(s) %s are not driven by any output",
 		strings.Join(e.Inputs.Names(), ", "),
@@ -113,6 +245,13 @@ func (e ErrorNoOutput) Error() string {
 }
 
 func (e ErrorNoInput) Error() string {
+	if len(e.Passives) > 0 {
+		return fmt.Sprintf(
+			"Output(s) %s are not driving any input, and are connected only to passive(s) %s",
+			strings.Join(e.Outputs.Names(), ", "),
+			strings.Join(e.Passives.Names(), ", "),
+		)
+	}
 	return fmt.Sprintf(
 		"Output(s) %s are not driving any input",
 		strings.Join(e.Outputs.Names(), ", "),
@@ -147,3 +286,168 @@ func (e ErrorNoConnectConnected) Error() string {
 		strings.Join(e.Endpoints.Names(), ", "),
 	)
 }
+
+func (e ErrorSupplyVoltage) Error() string {
+	return fmt.Sprintf(
+		"Power input(s) %s do not accept the %s to %s supplied by %s",
+		strings.Join(e.Loads.Names(), ", "),
+		e.Min.Format(units.FormatOptions{}), e.Max.Format(units.FormatOptions{}),
+		strings.Join(e.Supplies.Names(), ", "),
+	)
+}
+
+func (e ErrorOverVoltage) Error() string {
+	return fmt.Sprintf(
+		"Output(s) %s may drive up to %s, exceeding the absolute maximum rating of %s",
+		strings.Join(e.Drivers.Names(), ", "),
+		e.Max.Format(units.FormatOptions{}),
+		strings.Join(e.Driving.Names(), ", "),
+	)
+}
+
+func (e ErrorLogicLevelMismatch) Error() string {
+	var which string
+	switch {
+	case e.High && e.Low:
+		which = "high or low"
+	case e.High:
+		which = "high"
+	default:
+		which = "low"
+	}
+	return fmt.Sprintf(
+		"Output %s does not produce a logic %s level recognized by input %s",
+		e.Driver.Name, which, e.Receiver.Name,
+	)
+}
+
+func (e ErrorNoPullUp) Error() string {
+	return fmt.Sprintf(
+		"Open collector output(s) %s have no pull-up resistor",
+		strings.Join(e.Outputs.Names(), ", "),
+	)
+}
+
+func (e ErrorNoPullDown) Error() string {
+	return fmt.Sprintf(
+		"Open emitter output(s) %s have no pull-down resistor",
+		strings.Join(e.Outputs.Names(), ", "),
+	)
+}
+
+func (e ErrorFloatingBus) Error() string {
+	return fmt.Sprintf(
+		"Tristate output(s) %s have no pull resistor or bus keeper",
+		strings.Join(e.Outputs.Names(), ", "),
+	)
+}
+
+func (e ErrorFloatingPassives) Error() string {
+	return fmt.Sprintf(
+		"Passive(s) %s are not connected to any power or signal",
+		strings.Join(e.Passives.Names(), ", "),
+	)
+}
+
+func (e ErrorBidirectionalNoRole) Error() string {
+	return fmt.Sprintf(
+		"Bidirectional endpoint(s) %s have no leader or follower role",
+		strings.Join(e.Endpoints.Names(), ", "),
+	)
+}
+
+func (e ErrorMultipleLeaders) Error() string {
+	return fmt.Sprintf(
+		"Leaders %s are connected to each other",
+		strings.Join(e.Leaders.Names(), ", "),
+	)
+}
+
+func (e ErrorNoLeader) Error() string {
+	return fmt.Sprintf(
+		"Follower(s) %s have no leader",
+		strings.Join(e.Followers.Names(), ", "),
+	)
+}
+
+func (e ErrorUnjustifiedWaiver) Error() string {
+	on := "net"
+	switch {
+	case e.Endpoint != nil:
+		on = e.Endpoint.Name
+	case e.Net != nil && e.Net.SuggestedName() != "":
+		on = "net " + e.Net.SuggestedName()
+	}
+	return fmt.Sprintf(
+		"Waiver of rule %s on %s has no justification",
+		e.Waived, on,
+	)
+}
+
+func (e ErrorUnconnected) Rule() RuleID {
+	return RuleUnconnected
+}
+
+func (e ErrorNoConnectConnected) Rule() RuleID {
+	return RuleNoConnectConnected
+}
+
+func (e ErrorNoInput) Rule() RuleID {
+	return RuleNoInput
+}
+
+func (e ErrorNoOutput) Rule() RuleID {
+	return RuleNoOutput
+}
+
+func (e ErrorSignalAsPower) Rule() RuleID {
+	return RuleSignalAsPower
+}
+
+func (e ErrorOutputConflict) Rule() RuleID {
+	return RuleOutputConflict
+}
+
+func (e ErrorSupplyVoltage) Rule() RuleID {
+	return RuleSupplyVoltage
+}
+
+func (e ErrorOverVoltage) Rule() RuleID {
+	return RuleOverVoltage
+}
+
+func (e ErrorLogicLevelMismatch) Rule() RuleID {
+	return RuleLogicLevelMismatch
+}
+
+func (e ErrorNoPullUp) Rule() RuleID {
+	return RuleNoPullUp
+}
+
+func (e ErrorNoPullDown) Rule() RuleID {
+	return RuleNoPullDown
+}
+
+func (e ErrorFloatingBus) Rule() RuleID {
+	return RuleFloatingBus
+}
+
+func (e ErrorFloatingPassives) Rule() RuleID {
+	return RuleFloatingPassives
+}
+
+func (e ErrorBidirectionalNoRole) Rule() RuleID {
+	return RuleBidirectionalNoRole
+}
+
+func (e ErrorMultipleLeaders) Rule() RuleID {
+	return RuleMultipleLeaders
+}
+
+func (e ErrorNoLeader) Rule() RuleID {
+	return RuleNoLeader
+}
+
+func (e ErrorUnjustifiedWaiver) Rule() RuleID {
+	return RuleUnjustifiedWaiver
+}
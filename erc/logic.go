@@ -0,0 +1,90 @@
+package erc
+
+import (
+	"sort"
+
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// checkLogicLevels compares the logic levels of each output on a net with
+// the thresholds of each input on the same net, returning an error for each
+// driver/receiver pair that is incompatible.
+func (c *Checker) checkLogicLevels(classes classifications) Errors {
+	drivers := classes.Outputs.Union(classes.Bidis)
+	receivers := classes.Inputs.Union(classes.Bidis)
+	if len(drivers) == 0 || len(receivers) == 0 {
+		return nil
+	}
+	outClasses := classifyOutputs(drivers)
+
+	var errs []ErrorLogicLevelMismatch
+	for driver := range drivers {
+		if driver.Voltage == nil {
+			continue
+		}
+		// Open collector and open emitter outputs only actively drive one
+		// level, with the other determined by an external pull resistor, so
+		// we can check only the level they drive.
+		checkHigh := !outClasses.OpenCollector.Has(driver)
+		checkLow := !outClasses.OpenEmitter.Has(driver)
+
+		for receiver := range receivers {
+			if receiver == driver || receiver.Voltage == nil {
+				continue
+			}
+
+			high := checkHigh && !c.levelMeets(driver.Voltage.VOH, receiver.Voltage.VIH, 1)
+			low := checkLow && !c.levelMeets(driver.Voltage.VOL, receiver.Voltage.VIL, -1)
+			if high || low {
+				errs = append(errs, ErrorLogicLevelMismatch{
+					Driver:   driver,
+					Receiver: receiver,
+					High:     high,
+					Low:      low,
+				})
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// The endpoint sets are unordered, so we'll sort the result to make it
+	// deterministic.
+	sort.Slice(errs, func(i, j int) bool {
+		if a, b := errs[i].Driver.Name, errs[j].Driver.Name; a != b {
+			return a < b
+		}
+		return errs[i].Receiver.Name < errs[j].Receiver.Name
+	})
+	ret := make(Errors, len(errs))
+	for i, err := range errs {
+		ret[i] = err
+	}
+	return ret
+}
+
+// levelMeets returns true if the given output level meets the given input
+// threshold with the checker's noise margin to spare, in the direction
+// given by sign: 1 if the level must be above the threshold, or -1 if it
+// must be below.
+//
+// If either level is not specified then the check cannot be made, and so
+// the result is true.
+func (c *Checker) levelMeets(level, threshold *units.Quantity, sign int) bool {
+	if level == nil || threshold == nil {
+		return true
+	}
+
+	limit := *threshold
+	if c.NoiseMargin != nil {
+		if sign > 0 {
+			limit = limit.Add(*c.NoiseMargin)
+		} else {
+			limit = limit.Subtract(*c.NoiseMargin)
+		}
+	}
+
+	return level.Compare(limit)*sign >= 0
+}
@@ -0,0 +1,91 @@
+package erc
+
+import (
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// netGroups is a disjoint-set forest of cbo.Net, used to partition nets into
+// groups that are joined together.
+//
+// Nets are identified internally by their index in the order they were
+// added, so that the forest itself can be stored in flat slices rather than
+// maps.
+type netGroups struct {
+	nets   []*cbo.Net
+	index  map[*cbo.Net]int
+	parent []int
+	size   []int
+}
+
+func newNetGroups(capacity int) *netGroups {
+	return &netGroups{
+		nets:   make([]*cbo.Net, 0, capacity),
+		index:  make(map[*cbo.Net]int, capacity),
+		parent: make([]int, 0, capacity),
+		size:   make([]int, 0, capacity),
+	}
+}
+
+// Add adds the given net to the forest as a group of its own, if it is not
+// already present, and returns its index.
+func (g *netGroups) Add(n *cbo.Net) int {
+	if i, exists := g.index[n]; exists {
+		return i
+	}
+
+	i := len(g.nets)
+	g.nets = append(g.nets, n)
+	g.index[n] = i
+	g.parent = append(g.parent, i)
+	g.size = append(g.size, 1)
+	return i
+}
+
+// Len returns the number of nets in the forest.
+func (g *netGroups) Len() int {
+	return len(g.nets)
+}
+
+// Net returns the net with the given index.
+func (g *netGroups) Net(i int) *cbo.Net {
+	return g.nets[i]
+}
+
+// Find returns the index of the representative of the group containing the
+// net with the given index.
+func (g *netGroups) Find(i int) int {
+	root := i
+	for g.parent[root] != root {
+		root = g.parent[root]
+	}
+
+	// Compress the path we just walked so that future lookups for any of
+	// these nets will go directly to the root.
+	for g.parent[i] != root {
+		next := g.parent[i]
+		g.parent[i] = root
+		i = next
+	}
+
+	return root
+}
+
+// Union merges the groups containing the nets with the given indices.
+func (g *netGroups) Union(a, b int) {
+	ra, rb := g.Find(a), g.Find(b)
+	if ra == rb {
+		return
+	}
+
+	// Attach the smaller tree beneath the larger, to keep the trees shallow.
+	if g.size[ra] < g.size[rb] {
+		ra, rb = rb, ra
+	}
+	g.parent[rb] = ra
+	g.size[ra] += g.size[rb]
+}
+
+// Same returns true if the nets with the given indices are in the same group.
+func (g *netGroups) Same(a, b int) bool {
+	return g.Find(a) == g.Find(b)
+}
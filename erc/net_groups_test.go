@@ -0,0 +1,61 @@
+package erc
+
+import (
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+func TestNetGroups(t *testing.T) {
+	g := newNetGroups(2)
+
+	if got, want := g.Len(), 0; got != want {
+		t.Errorf("wrong initial length %d; want %d", got, want)
+	}
+
+	netA := &cbo.Net{}
+	netB := &cbo.Net{}
+	netC := &cbo.Net{}
+	netD := &cbo.Net{}
+
+	a := g.Add(netA)
+	if got, want := g.Add(netA), a; got != want {
+		t.Errorf("wrong index after adding A twice %d; want %d", got, want)
+	}
+	b := g.Add(netB)
+	c := g.Add(netC)
+	d := g.Add(netD) // extends beyond initial capacity
+
+	if got, want := g.Len(), 4; got != want {
+		t.Errorf("wrong length after adding all nets %d; want %d", got, want)
+	}
+	if got, want := g.Net(c), netC; got != want {
+		t.Errorf("wrong net for index %d", c)
+	}
+
+	if g.Same(a, b) {
+		t.Errorf("A and B in same group before union")
+	}
+
+	g.Union(a, b)
+	g.Union(c, b)
+
+	if !g.Same(a, c) {
+		t.Errorf("A and C not in same group after union")
+	}
+	if g.Same(a, d) {
+		t.Errorf("A and D in same group without union")
+	}
+	if got, want := g.Find(b), g.Find(a); got != want {
+		t.Errorf("B has representative %d; want %d", got, want)
+	}
+	if got, want := g.Find(d), d; got != want {
+		t.Errorf("D has representative %d; want %d", got, want)
+	}
+
+	// Union of two nets already in the same group must be a no-op.
+	g.Union(a, c)
+	if !g.Same(b, c) || g.Same(c, d) {
+		t.Errorf("groups changed by redundant union")
+	}
+}
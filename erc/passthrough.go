@@ -7,91 +7,91 @@ import (
 // FlattenPassthrough analyses the graph for "passthrough" edges and produces
 // a map from each of the given nets to the set of endpoints that interact
 // with that net, either directly or indirectly.
+//
+// Nets that are reachable from the given nets only through passthrough
+// endpoints are also included in the result.
+//
+// All of the nets that are linked together by passthrough endpoints receive
+// the same set, so callers must not modify the sets in the result.
 func FlattenPassthrough(nets []*cbo.Net) map[*cbo.Net]cbo.EndpointSet {
-	// What we're doing here is analogous to a data-flow analysis of a
-	// control flow graph in an imperative programming language, with
-	// the transfer function being the union of all of a net's endpoints
-	// and the passthrough endpoints for each endpoint.
+	// Passthrough links are symmetrical, so the nets they join form
+	// undirected connected components and every net in a component
+	// interacts with exactly the same endpoints. Rather than propagating
+	// endpoints from net to net until we reach a fixpoint, we find the
+	// components once using a disjoint-set forest and then build a single
+	// endpoint set for each component.
 
-	queue := newNetQueue(len(nets))
-	ret := make(map[*cbo.Net]cbo.EndpointSet, len(nets))
-
-	// Fill the queue with the nets we were given. The order here doesn't
-	// really matter, though we'll get better performance if the list happens
-	// to be ordered such that most or all of the passthrough results have
-	// already been evaluated before we reach a particular net.
+	groups := newNetGroups(len(nets))
 	for _, net := range nets {
-		queue.Append(net)
+		groups.Add(net)
 	}
 
-	// Keep iterating until our queue is empty. If we make any changes
-	// to a net's set then we'll add its passthroughs to the queue, so we
-	// are likely to visit each net multiple times but we should eventually
-	// converge on a fixpoint. We only ever grow the sets, so we know that
-	// _at worst_ we will add every endpoint to every set and then exit.
-	for current := queue.Take(); current != nil; current = queue.Take() {
-		set := ret[current]
-		if set == nil {
-			set = make(cbo.EndpointSet, len(current.Endpoints))
-			ret[current] = set
+	// Groups.Len grows as we discover nets on the far side of passthrough
+	// endpoints, so this loop visits every reachable net exactly once.
+	for i := 0; i < groups.Len(); i++ {
+		for ep := range groups.Net(i).Endpoints {
+			for bep := range ep.Passthrough {
+				if bep.Net == nil {
+					continue
+				}
+				groups.Union(i, groups.Add(bep.Net))
+			}
 		}
+	}
 
-		// We need to track if we add anything to our set, so we can re-queue
-		// our dependents in that case.
-		changed := false
-
-		for ep := range current.Endpoints {
-			if ep.Passthrough != nil {
-				// For endpoints with passthrough, we traverse their
-				// internal connection and import the endpoints from the
-				// nets on "the other side".
-				for bep := range ep.Passthrough {
-					if bep.Net == nil {
-						continue
-					}
+	sets := make(map[int]cbo.EndpointSet)
+	ret := make(map[*cbo.Net]cbo.EndpointSet, groups.Len())
+	for i := 0; i < groups.Len(); i++ {
+		net := groups.Net(i)
+		root := groups.Find(i)
+		set := sets[root]
+		if set == nil {
+			set = make(cbo.EndpointSet, len(net.Endpoints))
+			sets[root] = set
+		}
 
-					bset := ret[bep.Net]
-					for dep := range bset {
-						if dep.Passthrough != nil {
-							continue
-						}
+		for ep := range net.Endpoints {
+			// Endpoints with passthrough are represented by whatever is
+			// on "the other side", which is in this same component.
+			if ep.Passthrough == nil {
+				set.Add(ep)
+			}
+		}
 
-						if !set.Has(dep) {
-							set.Add(dep)
-							changed = true
-						}
-					}
-				}
+		ret[net] = set
+	}
 
-			} else {
-				// For endpoints _without_ passthrough, we just add them
-				// directly to our set.
+	return ret
+}
 
-				if !set.Has(ep) {
-					set.Add(ep)
-					changed = true
-				}
-			}
-		}
+// PassthroughLink describes a connection from one net to another made by a
+// passthrough endpoint, such as the two terminals of a resistor.
+type PassthroughLink struct {
+	// Near is the passthrough endpoint that belongs to the net in question.
+	Near *cbo.Endpoint
 
-		if changed {
-			// Make sure that dependent nets get re-processed to see
-			// our updated set.
-			for ep := range current.Endpoints {
-				if ep.Passthrough == nil {
-					continue
-				}
+	// Far is the endpoint on "the other side" of Near, whose net is the
+	// net that is linked.
+	Far *cbo.Endpoint
+}
 
-				for bep := range ep.Passthrough {
-					if bep.Net != nil {
-						// This might be a no-op, if this net was already
-						// in the queue anyway.
-						queue.Append(bep.Net)
-					}
-				}
+// PassthroughLinks returns the passthrough links from the given net to
+// other nets, in no particular order.
+//
+// Passthrough endpoints whose other side is not connected to any net are
+// not included.
+func PassthroughLinks(net *cbo.Net) []PassthroughLink {
+	var ret []PassthroughLink
+	for ep := range net.Endpoints {
+		for far := range ep.Passthrough {
+			if far.Net == nil {
+				continue
 			}
+			ret = append(ret, PassthroughLink{
+				Near: ep,
+				Far:  far,
+			})
 		}
 	}
-
 	return ret
 }
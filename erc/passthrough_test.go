@@ -1,6 +1,7 @@
 package erc
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/cirbo-lang/cirbo/cbo"
@@ -132,3 +133,90 @@ func testEndpointSet(items []*cbo.Endpoint) cbo.EndpointSet {
 	}
 	return ret
 }
+
+func TestFlattenPassthroughShared(t *testing.T) {
+	netA, netB, netC := testNet(), testNet(), testNet()
+	near := &cbo.Endpoint{}
+	far := &cbo.Endpoint{}
+	near.Passthrough = testEndpointSet([]*cbo.Endpoint{far})
+	far.Passthrough = testEndpointSet([]*cbo.Endpoint{near})
+	direct := &cbo.Endpoint{}
+
+	netA.Connect(near)
+	netB.Connect(far)
+	netB.Connect(direct)
+	netC.Connect(&cbo.Endpoint{})
+
+	// netB is reachable only through passthrough, so it is not passed in.
+	got := FlattenPassthrough([]*cbo.Net{netA, netC})
+
+	if got[netB] == nil {
+		t.Fatalf("result missing net reachable through passthrough")
+	}
+	if !got[netA].Has(direct) {
+		t.Errorf("result for netA missing endpoint from netB")
+	}
+
+	// Nets in the same component share a single set.
+	got[netA].Add(&cbo.Endpoint{})
+	if len(got[netB]) != 2 {
+		t.Errorf("netA and netB do not share an endpoint set")
+	}
+	if len(got[netC]) != 1 {
+		t.Errorf("netC shares an endpoint set with another component")
+	}
+}
+
+// BenchmarkFlattenPassthroughChain measures a single long chain of series
+// resistors, where every net is in the same component.
+func BenchmarkFlattenPassthroughChain(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		nets := benchNetChain(n, n)
+		b.Run(benchName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FlattenPassthrough(nets)
+			}
+		})
+	}
+}
+
+// BenchmarkFlattenPassthroughShortChains measures many short chains of
+// series resistors, as might be found on a backplane with a termination
+// network on each line.
+func BenchmarkFlattenPassthroughShortChains(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		nets := benchNetChain(n, 4)
+		b.Run(benchName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FlattenPassthrough(nets)
+			}
+		})
+	}
+}
+
+// benchNetChain returns n nets, each with two direct endpoints, in which
+// consecutive nets are joined by resistor-like passthrough endpoint pairs to
+// form chains of the given length.
+func benchNetChain(n, length int) []*cbo.Net {
+	nets := make([]*cbo.Net, n)
+	for i := range nets {
+		nets[i] = testNet()
+		nets[i].Connect(&cbo.Endpoint{})
+		nets[i].Connect(&cbo.Endpoint{})
+		if i%length == 0 {
+			continue
+		}
+
+		near := &cbo.Endpoint{}
+		far := &cbo.Endpoint{}
+		near.Passthrough = testEndpointSet([]*cbo.Endpoint{far})
+		far.Passthrough = testEndpointSet([]*cbo.Endpoint{near})
+		nets[i-1].Connect(near)
+		nets[i].Connect(far)
+	}
+	return nets
+}
+
+func benchName(n int) string {
+	return fmt.Sprintf("%d nets", n)
+}
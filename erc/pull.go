@@ -0,0 +1,105 @@
+package erc
+
+import (
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// groundNetNames are the suggested net names that are taken to indicate a
+// ground net for the purpose of the pull-down rules.
+var groundNetNames = map[string]bool{
+	"GND":  true,
+	"AGND": true,
+	"PGND": true,
+}
+
+// checkPulls checks that a net with open collector, open emitter or
+// tristate outputs has a suitable pull resistor or bus keeper.
+//
+// A pull resistor is any passthrough endpoint of Passive type that links the
+// given net to a power net. Only the endpoints directly on the given net are
+// considered, so that a pull resistor on one side of a series resistor does
+// not satisfy an output on the other side.
+func checkPulls(net *cbo.Net) Errors {
+	direct := make(cbo.EndpointSet, len(net.Endpoints))
+	for ep := range net.Endpoints {
+		if ep.Passthrough == nil {
+			direct.Add(ep)
+		}
+	}
+	classes := classify(direct)
+	outClasses := classifyOutputs(classes.Outputs.Union(classes.Bidis))
+
+	if len(outClasses.OpenCollector) == 0 && len(outClasses.OpenEmitter) == 0 && len(outClasses.Tristate) == 0 {
+		// Fast path for the common case where none of the rules apply.
+		return nil
+	}
+
+	var pullUp, pullDown bool
+	for _, link := range PassthroughLinks(net) {
+		if link.Near.ERC.Type != cbo.Passive {
+			continue
+		}
+		switch {
+		case isGroundNet(link.Far.Net):
+			pullDown = true
+		case isPowerNet(link.Far.Net):
+			pullUp = true
+		}
+	}
+
+	var errs Errors
+
+	if len(outClasses.OpenCollector) > 0 && !pullUp {
+		errs = append(errs, ErrorNoPullUp{
+			Outputs: outClasses.OpenCollector,
+		})
+	}
+
+	if len(outClasses.OpenEmitter) > 0 && !pullDown {
+		errs = append(errs, ErrorNoPullDown{
+			Outputs: outClasses.OpenEmitter,
+		})
+	}
+
+	tristateOnly := len(outClasses.Tristate) > 0 &&
+		len(outClasses.PushPull) == 0 &&
+		len(outClasses.OpenCollector) == 0 &&
+		len(outClasses.OpenEmitter) == 0
+	if tristateOnly && !pullUp && !pullDown && len(classes.BusKeeperFlags) == 0 {
+		errs = append(errs, ErrorFloatingBus{
+			Outputs: outClasses.Tristate,
+		})
+	}
+
+	return errs
+}
+
+// isPowerNet returns true if the given net has at least one endpoint of
+// Power type directly connected to it.
+func isPowerNet(net *cbo.Net) bool {
+	for ep := range net.Endpoints {
+		if ep.Passthrough == nil && ep.ERC.Type == cbo.Power {
+			return true
+		}
+	}
+	return false
+}
+
+// isGroundNet returns true if the given net appears to be a ground net,
+// either because it has a conventional ground name or because it is
+// supplied by a power output specified as producing zero volts.
+func isGroundNet(net *cbo.Net) bool {
+	if groundNetNames[net.SuggestedName()] {
+		return true
+	}
+	for ep := range net.Endpoints {
+		if ep.ERC.Type != cbo.Power || ep.ERC.Dir != cbo.Output {
+			continue
+		}
+		_, max := ep.Voltage.SupplyRange()
+		if max != nil && max.Value().Sign() == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package erc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/davecgh/go-spew/spew"
+)
+
+func TestCheckPulls(t *testing.T) {
+	type testCase struct {
+		Net  *cbo.Net
+		Want Errors
+	}
+
+	// testResistor returns the two endpoints of a passive passthrough
+	// device, connected to the two given nets.
+	testResistor := func(a, b *cbo.Net) (*cbo.Endpoint, *cbo.Endpoint) {
+		epA := &cbo.Endpoint{Name: "R.1"}
+		epB := &cbo.Endpoint{Name: "R.2"}
+		epA.Passthrough = cbo.NewEndpointSet(epB)
+		epB.Passthrough = cbo.NewEndpointSet(epA)
+		a.Connect(epA)
+		b.Connect(epB)
+		return epA, epB
+	}
+	testRail := func(name string) *cbo.Net {
+		net := testNet()
+		net.Connect(&cbo.Endpoint{
+			Name: name,
+			ERC: cbo.ERCMode{
+				Type: cbo.Power,
+				Dir:  cbo.Output,
+			},
+		})
+		return net
+	}
+	testOutput := func(name string, outType cbo.ERCOutputType) *cbo.Endpoint {
+		return &cbo.Endpoint{
+			Name: name,
+			ERC: cbo.ERCMode{
+				Type:       cbo.Signal,
+				Dir:        cbo.Bidirectional,
+				OutputType: outType,
+			},
+		}
+	}
+
+	tests := map[string]func() testCase{
+		"push-pull only": func() testCase {
+			net := testNet()
+			net.Connect(testOutput("OUT", cbo.PushPull))
+			return testCase{net, Errors(nil)}
+		},
+		"open collector with pull-up": func() testCase {
+			net := testNet()
+			net.Connect(testOutput("SDA", cbo.OpenCollector))
+			testResistor(net, testRail("VCC"))
+			return testCase{net, Errors(nil)}
+		},
+		"open collector without pull-up": func() testCase {
+			net := testNet()
+			sda1 := testOutput("SDA1", cbo.OpenCollector)
+			sda2 := testOutput("SDA2", cbo.OpenCollector)
+			net.Connect(sda1)
+			net.Connect(sda2)
+			return testCase{
+				net,
+				Errors{
+					ErrorNoPullUp{
+						Outputs: cbo.NewEndpointSet(sda1, sda2),
+					},
+				},
+			}
+		},
+		"open collector with resistor to ground only": func() testCase {
+			net := testNet()
+			sda := testOutput("SDA", cbo.OpenCollector)
+			net.Connect(sda)
+			testResistor(net, testRail("GND"))
+			return testCase{
+				net,
+				Errors{
+					ErrorNoPullUp{
+						Outputs: cbo.NewEndpointSet(sda),
+					},
+				},
+			}
+		},
+		"open collector with series resistor": func() testCase {
+			net := testNet()
+			sda := testOutput("SDA", cbo.OpenCollector)
+			net.Connect(sda)
+			testResistor(net, testNet())
+			return testCase{
+				net,
+				Errors{
+					ErrorNoPullUp{
+						Outputs: cbo.NewEndpointSet(sda),
+					},
+				},
+			}
+		},
+		"open emitter with pull-down": func() testCase {
+			net := testNet()
+			net.Connect(testOutput("OUT", cbo.OpenEmitter))
+			testResistor(net, testRail("GND"))
+			return testCase{net, Errors(nil)}
+		},
+		"open emitter without pull-down": func() testCase {
+			net := testNet()
+			out := testOutput("OUT", cbo.OpenEmitter)
+			net.Connect(out)
+			testResistor(net, testRail("VCC"))
+			return testCase{
+				net,
+				Errors{
+					ErrorNoPullDown{
+						Outputs: cbo.NewEndpointSet(out),
+					},
+				},
+			}
+		},
+		"tristate without pull": func() testCase {
+			net := testNet()
+			d0 := testOutput("D0", cbo.Tristate)
+			net.Connect(d0)
+			return testCase{
+				net,
+				Errors{
+					ErrorFloatingBus{
+						Outputs: cbo.NewEndpointSet(d0),
+					},
+				},
+			}
+		},
+		"tristate with pull-down": func() testCase {
+			net := testNet()
+			net.Connect(testOutput("D0", cbo.Tristate))
+			testResistor(net, testRail("GND"))
+			return testCase{net, Errors(nil)}
+		},
+		"tristate with bus keeper": func() testCase {
+			net := testNet()
+			net.Connect(testOutput("D0", cbo.Tristate))
+			net.Connect(&cbo.Endpoint{
+				Name: "KEEP",
+				ERC: cbo.ERCMode{
+					Type: cbo.Passive,
+					Dir:  cbo.BusKeeperFlag,
+				},
+			})
+			return testCase{net, Errors(nil)}
+		},
+	}
+
+	spewer := spew.NewDefaultConfig()
+	spewer.DisableMethods = true
+
+	for name, cons := range tests {
+		t.Run(name, func(t *testing.T) {
+			test := cons()
+			got := checkPulls(test.Net)
+			if !reflect.DeepEqual(got, test.Want) {
+				t.Errorf("wrong result\ngot: %swant: %s", spewer.Sdump(got), spewer.Sdump(test.Want))
+			}
+		})
+	}
+}
+
+func TestPassthroughLinks(t *testing.T) {
+	a := testNet()
+	b := testNet()
+	epA := &cbo.Endpoint{Name: "R.1"}
+	epB := &cbo.Endpoint{Name: "R.2"}
+	epA.Passthrough = cbo.NewEndpointSet(epB)
+	epB.Passthrough = cbo.NewEndpointSet(epA)
+	a.Connect(epA)
+	a.Connect(&cbo.Endpoint{Name: "OTHER"})
+	b.Connect(epB)
+
+	got := PassthroughLinks(a)
+	want := []PassthroughLink{
+		{Near: epA, Far: epB},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
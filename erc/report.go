@@ -0,0 +1,69 @@
+package erc
+
+import (
+	"sort"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// Finding is a single rule violation found by Checker.Report.
+type Finding struct {
+	Net      *cbo.Net
+	Error    Error
+	Severity Severity
+
+	// Waiver is the waiver that covers the finding, or nil if the finding
+	// is not waived. Waived findings do not cause a check to fail, but are
+	// included in reports so that their justifications can be reviewed.
+	Waiver *cbo.Waiver
+}
+
+// Waived returns true if the finding is covered by a waiver.
+func (f Finding) Waived() bool {
+	return f.Waiver != nil
+}
+
+// Fatal returns true if the finding is an unwaived violation of a rule
+// whose severity is SeverityError.
+func (f Finding) Fatal() bool {
+	return f.Severity == SeverityError && !f.Waived()
+}
+
+// Report applies the same rules as CheckNets to the given nets, but returns
+// a finding for every violation of a rule that is not turned off, including
+// waived violations.
+//
+// Findings are sorted by rule, in catalogue order, and then by message, so
+// that the result is deterministic for a given design.
+func (c *Checker) Report(endpointSets map[*cbo.Net]cbo.EndpointSet) []Finding {
+	var ret []Finding
+
+	for net, endpoints := range endpointSets {
+		for _, err := range c.checkNetFull(net, endpoints) {
+			sev := c.Severity(err.Rule())
+			if sev == SeverityOff {
+				continue
+			}
+			ret = append(ret, Finding{
+				Net:      net,
+				Error:    err,
+				Severity: sev,
+				Waiver:   waiverFor(err.Rule(), net, endpoints),
+			})
+		}
+	}
+
+	order := make(map[RuleID]int, len(rules))
+	for i, rule := range rules {
+		order[rule.ID] = i
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		a, b := ret[i].Error, ret[j].Error
+		if oa, ob := order[a.Rule()], order[b.Rule()]; oa != ob {
+			return oa < ob
+		}
+		return a.Error() < b.Error()
+	})
+
+	return ret
+}
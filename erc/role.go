@@ -0,0 +1,37 @@
+package erc
+
+import (
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// checkRoles checks that the leader/follower roles of the endpoints on a
+// net are consistent with one another.
+func checkRoles(classes classifications) Errors {
+	var errs Errors
+
+	noRole := make(cbo.EndpointSet)
+	for e := range classes.Bidis {
+		if e.Role == cbo.NoRole {
+			noRole.Add(e)
+		}
+	}
+	if len(noRole) > 0 {
+		errs = append(errs, ErrorBidirectionalNoRole{
+			Endpoints: noRole,
+		})
+	}
+
+	if len(classes.Leaders) > 1 && len(classes.MultiLeaderFlags) == 0 {
+		errs = append(errs, ErrorMultipleLeaders{
+			Leaders: classes.Leaders,
+		})
+	}
+
+	if len(classes.Followers) > 0 && len(classes.Leaders) == 0 {
+		errs = append(errs, ErrorNoLeader{
+			Followers: classes.Followers,
+		})
+	}
+
+	return errs
+}
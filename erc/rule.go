@@ -0,0 +1,99 @@
+package erc
+
+// RuleID is a stable identifier for one of the electrical rules. Rule IDs
+// are used to configure rule severities and to waive rules, and so they will
+// not change between releases.
+type RuleID string
+
+const (
+	RuleUnconnected         RuleID = "unconnected"
+	RuleNoConnectConnected  RuleID = "no-connect-connected"
+	RuleNoInput             RuleID = "no-input"
+	RuleNoOutput            RuleID = "no-output"
+	RuleSignalAsPower       RuleID = "signal-as-power"
+	RuleOutputConflict      RuleID = "output-conflict"
+	RuleSupplyVoltage       RuleID = "supply-voltage"
+	RuleOverVoltage         RuleID = "over-voltage"
+	RuleLogicLevelMismatch  RuleID = "logic-level-mismatch"
+	RuleNoPullUp            RuleID = "no-pull-up"
+	RuleNoPullDown          RuleID = "no-pull-down"
+	RuleFloatingBus         RuleID = "floating-bus"
+	RuleFloatingPassives    RuleID = "floating-passives"
+	RuleBidirectionalNoRole RuleID = "bidirectional-no-role"
+	RuleMultipleLeaders     RuleID = "multiple-leaders"
+	RuleNoLeader            RuleID = "no-leader"
+	RuleUnjustifiedWaiver   RuleID = "unjustified-waiver"
+)
+
+// Severity describes how a violation of a particular rule is treated.
+type Severity rune
+
+//go:generate stringer -type=Severity
+
+const (
+	// DefaultSeverity, when used in Checker.Severities, selects the rule's
+	// default severity.
+	DefaultSeverity Severity = 0
+
+	// SeverityError indicates that a rule violation is fatal.
+	SeverityError Severity = 'E'
+
+	// SeverityWarning indicates that a rule violation should be reported
+	// but is not fatal.
+	SeverityWarning Severity = 'W'
+
+	// SeverityOff indicates that a rule is not checked at all.
+	SeverityOff Severity = '-'
+)
+
+// Rule describes one of the electrical rules in the catalogue.
+type Rule struct {
+	ID              RuleID
+	Summary         string
+	DefaultSeverity Severity
+}
+
+// rules is the catalogue of all of the electrical rules, in the order
+// they are documented.
+var rules = []Rule{
+	{RuleUnconnected, "A net has only one endpoint.", SeverityError},
+	{RuleNoConnectConnected, "A net flagged as no-connect has other endpoints.", SeverityError},
+	{RuleNoInput, "Outputs on a net are not driving any input.", SeverityError},
+	{RuleNoOutput, "Inputs on a net are not driven by any output.", SeverityError},
+	{RuleSignalAsPower, "A signal output is driving a power input.", SeverityError},
+	{RuleOutputConflict, "Incompatible outputs are driving the same net.", SeverityError},
+	{RuleSupplyVoltage, "A power input does not accept the voltage supplied to it.", SeverityError},
+	{RuleOverVoltage, "Outputs may exceed the absolute maximum rating of an endpoint.", SeverityError},
+	{RuleLogicLevelMismatch, "An output's logic levels are not recognized by an input.", SeverityError},
+	{RuleNoPullUp, "Open collector outputs have no pull-up resistor.", SeverityError},
+	{RuleNoPullDown, "Open emitter outputs have no pull-down resistor.", SeverityError},
+	{RuleFloatingBus, "Tristate outputs have no pull resistor or bus keeper.", SeverityError},
+	{RuleFloatingPassives, "A net of passives is not connected to any power or signal.", SeverityError},
+	{RuleBidirectionalNoRole, "A bidirectional endpoint has no leader or follower role.", SeverityError},
+	{RuleMultipleLeaders, "Multiple leaders are connected to the same net.", SeverityError},
+	{RuleNoLeader, "Followers are connected to a net with no leader.", SeverityError},
+	{RuleUnjustifiedWaiver, "A waiver has no justification, and so is not honored.", SeverityError},
+}
+
+var rulesByID map[RuleID]Rule
+
+func init() {
+	rulesByID = make(map[RuleID]Rule, len(rules))
+	for _, rule := range rules {
+		rulesByID[rule.ID] = rule
+	}
+}
+
+// Rules returns the catalogue of all of the electrical rules.
+func Rules() []Rule {
+	ret := make([]Rule, len(rules))
+	copy(ret, rules)
+	return ret
+}
+
+// LookupRule returns the rule with the given ID, or false if there is no
+// such rule.
+func LookupRule(id RuleID) (Rule, bool) {
+	rule, ok := rulesByID[id]
+	return rule, ok
+}
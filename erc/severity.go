@@ -0,0 +1,99 @@
+package erc
+
+import (
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// Severity returns the effective severity of the rule with the given ID,
+// taking into account the receiver's configuration.
+func (c *Checker) Severity(id RuleID) Severity {
+	if sev := c.Severities[id]; sev != DefaultSeverity {
+		return sev
+	}
+	if rule, ok := LookupRule(id); ok {
+		return rule.DefaultSeverity
+	}
+	return SeverityError
+}
+
+// Fatal returns true if any of the given errors is a violation of a rule
+// whose effective severity is SeverityError.
+func (c *Checker) Fatal(errs Errors) bool {
+	for _, err := range errs {
+		if c.Severity(err.Rule()) == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// waiverFor returns a justified waiver for the given rule from the given
+// net or from any of the given endpoints, or nil if the rule is not waived.
+// The net may be nil, in which case only endpoint waivers are considered.
+func waiverFor(id RuleID, net *cbo.Net, endpoints cbo.EndpointSet) *cbo.Waiver {
+	if net != nil {
+		if w := findWaiver(id, net.Waivers); w != nil {
+			return w
+		}
+	}
+	for ep := range endpoints {
+		if w := findWaiver(id, ep.Waivers); w != nil {
+			return w
+		}
+	}
+	return nil
+}
+
+func findWaiver(id RuleID, waivers []cbo.Waiver) *cbo.Waiver {
+	for i := range waivers {
+		w := &waivers[i]
+		if RuleID(w.Rule) == id && w.Justified() {
+			return w
+		}
+	}
+	return nil
+}
+
+// checkWaivers returns an error for each waiver without a justification on
+// the given net or any of the given endpoints. The net may be nil, in which
+// case only endpoint waivers are considered.
+func checkWaivers(net *cbo.Net, endpoints cbo.EndpointSet) Errors {
+	var errs Errors
+	if net != nil {
+		for _, w := range net.Waivers {
+			if !w.Justified() {
+				errs = append(errs, ErrorUnjustifiedWaiver{
+					Waived: RuleID(w.Rule),
+					Net:    net,
+				})
+			}
+		}
+	}
+	for ep := range endpoints {
+		for _, w := range ep.Waivers {
+			if !w.Justified() {
+				errs = append(errs, ErrorUnjustifiedWaiver{
+					Waived:   RuleID(w.Rule),
+					Endpoint: ep,
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// filter removes from the given errors any that are for rules that are
+// turned off or waived.
+func (c *Checker) filter(net *cbo.Net, endpoints cbo.EndpointSet, errs Errors) Errors {
+	var ret Errors
+	for _, err := range errs {
+		if c.Severity(err.Rule()) == SeverityOff {
+			continue
+		}
+		if waiverFor(err.Rule(), net, endpoints) != nil {
+			continue
+		}
+		ret = append(ret, err)
+	}
+	return ret
+}
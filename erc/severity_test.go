@@ -0,0 +1,196 @@
+package erc
+
+import (
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+func TestCheckerSeverity(t *testing.T) {
+	in := &cbo.Endpoint{
+		Name: "IN",
+		ERC: cbo.ERCMode{
+			Type: cbo.Signal,
+			Dir:  cbo.Input,
+		},
+	}
+	other := &cbo.Endpoint{
+		Name: "IN2",
+		ERC: cbo.ERCMode{
+			Type: cbo.Signal,
+			Dir:  cbo.Input,
+		},
+	}
+	s := cbo.NewEndpointSet(in, other)
+
+	t.Run("default", func(t *testing.T) {
+		c := &Checker{}
+		errs := c.CheckNet(s)
+		if len(errs) != 1 {
+			t.Fatalf("wrong number of errors %d; want 1", len(errs))
+		}
+		if got, want := errs[0].Rule(), RuleNoOutput; got != want {
+			t.Errorf("wrong rule %q; want %q", got, want)
+		}
+		if !c.Fatal(errs) {
+			t.Errorf("errors are not fatal; want fatal")
+		}
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		c := &Checker{
+			Severities: map[RuleID]Severity{
+				RuleNoOutput: SeverityWarning,
+			},
+		}
+		errs := c.CheckNet(s)
+		if len(errs) != 1 {
+			t.Fatalf("wrong number of errors %d; want 1", len(errs))
+		}
+		if c.Fatal(errs) {
+			t.Errorf("errors are fatal; want non-fatal")
+		}
+	})
+
+	t.Run("off", func(t *testing.T) {
+		c := &Checker{
+			Severities: map[RuleID]Severity{
+				RuleNoOutput: SeverityOff,
+			},
+		}
+		if errs := c.CheckNet(s); errs != nil {
+			t.Errorf("unexpected errors: %s", errs)
+		}
+	})
+}
+
+func TestCheckerWaivers(t *testing.T) {
+	newNet := func(waivers ...cbo.Waiver) (*cbo.Net, *cbo.Endpoint) {
+		net := testNet()
+		in := &cbo.Endpoint{
+			Name: "IN",
+			ERC: cbo.ERCMode{
+				Type: cbo.Signal,
+				Dir:  cbo.Input,
+			},
+			Waivers: waivers,
+		}
+		net.Connect(in)
+		net.Connect(&cbo.Endpoint{
+			Name: "IN2",
+			ERC: cbo.ERCMode{
+				Type: cbo.Signal,
+				Dir:  cbo.Input,
+			},
+		})
+		return net, in
+	}
+	var c Checker
+
+	t.Run("endpoint waiver", func(t *testing.T) {
+		net, _ := newNet(cbo.Waiver{
+			Rule:          string(RuleNoOutput),
+			Justification: "driven by test fixture",
+		})
+		if errs := c.CheckNet(net.Endpoints); errs != nil {
+			t.Errorf("unexpected errors: %s", errs)
+		}
+	})
+
+	t.Run("waiver without justification", func(t *testing.T) {
+		net, _ := newNet(cbo.Waiver{
+			Rule: string(RuleNoOutput),
+		})
+		errs := c.CheckNet(net.Endpoints)
+		if len(errs) != 2 {
+			t.Fatalf("wrong number of errors %d; want 2", len(errs))
+		}
+		got := map[RuleID]bool{}
+		for _, err := range errs {
+			got[err.Rule()] = true
+		}
+		if !got[RuleNoOutput] || !got[RuleUnjustifiedWaiver] {
+			t.Errorf("wrong errors %s; want no-output and unjustified-waiver", errs)
+		}
+	})
+
+	t.Run("net waiver without justification", func(t *testing.T) {
+		net, _ := newNet()
+		net.Waivers = []cbo.Waiver{
+			{Rule: string(RuleNoOutput)},
+		}
+		sets := map[*cbo.Net]cbo.EndpointSet{
+			net: net.Endpoints,
+		}
+		findings := c.Report(sets)
+		if len(findings) != 2 {
+			t.Fatalf("wrong number of findings %d; want 2", len(findings))
+		}
+		f := findings[1]
+		if got, want := f.Error.Rule(), RuleUnjustifiedWaiver; got != want {
+			t.Errorf("wrong rule %q; want %q", got, want)
+		}
+		if !f.Fatal() {
+			t.Errorf("unjustified waiver is not fatal")
+		}
+	})
+
+	t.Run("waiver for another rule", func(t *testing.T) {
+		net, _ := newNet(cbo.Waiver{
+			Rule:          string(RuleNoInput),
+			Justification: "not relevant",
+		})
+		if errs := c.CheckNet(net.Endpoints); len(errs) != 1 {
+			t.Errorf("wrong number of errors %d; want 1", len(errs))
+		}
+	})
+
+	t.Run("net waiver", func(t *testing.T) {
+		net, _ := newNet()
+		net.Waivers = []cbo.Waiver{
+			{
+				Rule:          string(RuleNoOutput),
+				Justification: "driven by test fixture",
+			},
+		}
+		sets := map[*cbo.Net]cbo.EndpointSet{
+			net: net.Endpoints,
+		}
+		if errs := c.CheckNets(sets); errs != nil {
+			t.Errorf("unexpected errors: %#v", errs)
+		}
+
+		findings := c.Report(sets)
+		if len(findings) != 1 {
+			t.Fatalf("wrong number of findings %d; want 1", len(findings))
+		}
+		f := findings[0]
+		if !f.Waived() {
+			t.Fatalf("finding is not waived")
+		}
+		if f.Fatal() {
+			t.Errorf("waived finding is fatal")
+		}
+		if got, want := f.Waiver.Justification, "driven by test fixture"; got != want {
+			t.Errorf("wrong justification %q; want %q", got, want)
+		}
+	})
+}
+
+func TestRules(t *testing.T) {
+	seen := map[RuleID]bool{}
+	for _, rule := range Rules() {
+		if seen[rule.ID] {
+			t.Errorf("duplicate rule ID %q", rule.ID)
+		}
+		seen[rule.ID] = true
+
+		got, ok := LookupRule(rule.ID)
+		if !ok || got != rule {
+			t.Errorf("LookupRule(%q) returned %#v, %t", rule.ID, got, ok)
+		}
+		if rule.Summary == "" {
+			t.Errorf("rule %q has no summary", rule.ID)
+		}
+	}
+}
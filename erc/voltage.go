@@ -0,0 +1,112 @@
+package erc
+
+import (
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// voltageRange is the range of voltages that may appear on a net, along
+// with the endpoints that contribute to it.
+type voltageRange struct {
+	Min, Max *units.Quantity
+	Sources  cbo.EndpointSet
+}
+
+func (r *voltageRange) include(ep *cbo.Endpoint, min, max *units.Quantity) {
+	if min == nil && max == nil {
+		return
+	}
+	if min == nil {
+		min = max
+	}
+	if max == nil {
+		max = min
+	}
+	if r.Min == nil || min.Compare(*r.Min) < 0 {
+		r.Min = min
+	}
+	if r.Max == nil || max.Compare(*r.Max) > 0 {
+		r.Max = max
+	}
+	if r.Sources == nil {
+		r.Sources = make(cbo.EndpointSet)
+	}
+	r.Sources.Add(ep)
+}
+
+// netVoltages determines the voltages on a net from the outputs connected
+// to it. The supply range includes only power outputs, while the drive
+// range also includes the logic levels of signal outputs.
+func netVoltages(endpoints cbo.EndpointSet) (supply, drive voltageRange) {
+	for e := range endpoints {
+		if e.Voltage == nil {
+			continue
+		}
+		if e.ERC.Dir != cbo.Output && e.ERC.Dir != cbo.Bidirectional {
+			continue
+		}
+
+		switch e.ERC.Type {
+		case cbo.Power:
+			min, max := e.Voltage.SupplyRange()
+			supply.include(e, min, max)
+			drive.include(e, min, max)
+		case cbo.Signal:
+			drive.include(e, e.Voltage.VOL, e.Voltage.VOH)
+		}
+	}
+	return supply, drive
+}
+
+// checkVoltages applies the voltage domain rules to the given set of
+// endpoints, all belonging to the same net.
+func checkVoltages(endpoints cbo.EndpointSet) Errors {
+	var errs Errors
+
+	supply, drive := netVoltages(endpoints)
+
+	if supply.Sources != nil {
+		loads := make(cbo.EndpointSet)
+		for e := range endpoints {
+			if e.ERC.Type != cbo.Power || e.ERC.Dir != cbo.Input {
+				continue
+			}
+			min, max := e.Voltage.SupplyRange()
+			if min == nil {
+				continue
+			}
+			if supply.Min.Compare(*min) < 0 || supply.Max.Compare(*max) > 0 {
+				loads.Add(e)
+			}
+		}
+		if len(loads) > 0 {
+			errs = append(errs, ErrorSupplyVoltage{
+				Supplies: supply.Sources,
+				Loads:    loads,
+				Min:      *supply.Min,
+				Max:      *supply.Max,
+			})
+		}
+	}
+
+	if drive.Sources != nil {
+		victims := make(cbo.EndpointSet)
+		for e := range endpoints {
+			if drive.Sources.Has(e) || e.Voltage == nil || e.Voltage.AbsMax == nil {
+				continue
+			}
+			if drive.Max.Compare(*e.Voltage.AbsMax) > 0 {
+				victims.Add(e)
+			}
+		}
+		if len(victims) > 0 {
+			errs = append(errs, ErrorOverVoltage{
+				Drivers: drive.Sources,
+				Driving: victims,
+				Max:     *drive.Max,
+			})
+		}
+	}
+
+	return errs
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/cirbo-lang/cirbo/cbty"
 	"github.com/cirbo-lang/cirbo/source"
+	"github.com/cirbo-lang/cirbo/units"
 )
 
 // Context represents the current values for a set of symbols used during
@@ -85,6 +86,24 @@ func (ctx *Context) Value(sym *Symbol) cbty.Value {
 	return cbty.NilValue
 }
 
+// UnitByName returns the unit defined under the given name in the receiver
+// or the nearest defining ancestor context, or nil if no such unit is
+// defined. This allows functions that accept a unit name as a string to find
+// units declared in packages; see cbty.UnitResolver.
+func (ctx *Context) UnitByName(name string) *units.Unit {
+	current := ctx
+	for current != nil {
+		for sym, val := range current.values {
+			if sym.name == name && val.Type().Same(cbty.Unit) && !val.IsUnknown() {
+				return val.AsUnit()
+			}
+		}
+		current = current.parent
+	}
+
+	return nil
+}
+
 // AllValues returns a map describing the values of all of all of the symbols
 // defined in the given scope, using their definition names.
 //
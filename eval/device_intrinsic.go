@@ -0,0 +1,96 @@
+package eval
+
+import (
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/cbty"
+)
+
+// intrinsicDevices returns the devices that are implemented in Go and
+// included in the global scope.
+//
+// These are all ERC-only pseudo-devices, which produce terminals with the
+// special ERC directions that cannot be declared directly in the language,
+// allowing the user to explain unusual situations to the rules checker.
+func intrinsicDevices() map[string]*device {
+	return map[string]*device{
+		// no_connect marks the single endpoint it is connected to as
+		// intentionally unconnected.
+		"no_connect": intrinsicDevice(
+			"no_connect",
+			cbo.Terminal{
+				Name: "NC",
+				ERC:  cbo.ERCMode{Type: cbo.Passive, Dir: cbo.NoConnectFlag},
+			},
+		),
+
+		// multi_output_sink allows several outputs connected to its SINK
+		// terminal to be treated as a single output on its OUT terminal.
+		"multi_output_sink": intrinsicDevice(
+			"multi_output_sink",
+			cbo.Terminal{
+				Name: "SINK",
+				ERC:  cbo.ERCMode{Type: cbo.Passive, Dir: cbo.MultiOutputSinkFlag},
+			},
+			cbo.Terminal{
+				Name: "OUT",
+				ERC:  cbo.ERCMode{Type: cbo.Signal, Dir: cbo.Output, OutputType: cbo.PushPull},
+			},
+		),
+
+		// power_flag allows a signal output connected to its IN terminal to
+		// supply power inputs connected to its OUT terminal.
+		"power_flag": intrinsicDevice(
+			"power_flag",
+			cbo.Terminal{
+				Name: "IN",
+				ERC:  cbo.ERCMode{Type: cbo.Signal, Dir: cbo.Input},
+			},
+			cbo.Terminal{
+				Name: "OUT",
+				ERC:  cbo.ERCMode{Type: cbo.Power, Dir: cbo.Output, OutputType: cbo.PushPull},
+			},
+		),
+
+		// placeholder_input and placeholder_output stand in for an input or
+		// output that is not part of the design, such as one provided by
+		// an off-board connection.
+		"placeholder_input": intrinsicDevice(
+			"placeholder_input",
+			cbo.Terminal{
+				Name: "IN",
+				ERC:  cbo.ERCMode{Type: cbo.Passive, Dir: cbo.Input},
+			},
+		),
+		"placeholder_output": intrinsicDevice(
+			"placeholder_output",
+			cbo.Terminal{
+				Name: "OUT",
+				ERC:  cbo.ERCMode{Type: cbo.Passive, Dir: cbo.Output, OutputType: cbo.UnknownOutput},
+			},
+		),
+	}
+}
+
+// intrinsicDevice constructs an ERC-only device with no attributes and the
+// given terminals.
+func intrinsicDevice(name string, terms ...cbo.Terminal) *device {
+	dev := &device{
+		name:    name,
+		attrs:   StmtBlockAttrs{},
+		ercOnly: true,
+		terminals: cbo.TerminalsDef{
+			All:   make(map[string]cbo.Terminal, len(terms)),
+			Names: make([]string, len(terms)),
+		},
+	}
+	for i, term := range terms {
+		dev.terminals.All[term.Name] = term
+		dev.terminals.Names[i] = term.Name
+	}
+	dev.instTy = deviceInstanceType(dev)
+	dev.callSig = &cbty.CallSignature{
+		Parameters: map[string]cbty.CallParameter{},
+		Result:     dev.instTy,
+	}
+	return dev
+}
@@ -0,0 +1,57 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/cbty"
+)
+
+func TestIntrinsicDevices(t *testing.T) {
+	tests := map[string][]cbo.ERCDir{
+		"no_connect":         {cbo.NoConnectFlag},
+		"multi_output_sink":  {cbo.MultiOutputSinkFlag, cbo.Output},
+		"power_flag":         {cbo.Input, cbo.Output},
+		"placeholder_input":  {cbo.Input},
+		"placeholder_output": {cbo.Output},
+	}
+
+	for name, wantDirs := range tests {
+		t.Run(name, func(t *testing.T) {
+			sym := GlobalScope().Get(name)
+			if sym == nil {
+				t.Fatalf("%s is not in the global scope", name)
+			}
+			devVal := GlobalContext().Value(sym)
+
+			instVal, diags := devVal.Call(cbty.CallArgs{
+				Explicit:   map[string]cbty.Value{},
+				TargetName: "flag",
+				Context:    GlobalContext().NewChild(),
+			})
+			if len(diags) != 0 {
+				t.Fatalf("unexpected diagnostics: %#v", diags)
+			}
+
+			unwr := &Unwrapper{}
+			inst, ok := unwr.Unwrap(instVal).(*cbo.DeviceInstance)
+			if !ok {
+				t.Fatalf("result is %#v; want device instance", unwr.Unwrap(instVal))
+			}
+			if !inst.Device.ERCOnly {
+				t.Errorf("device is not ERC-only")
+			}
+
+			termNames := inst.TerminalNames()
+			if len(termNames) != len(wantDirs) {
+				t.Fatalf("wrong number of terminals %d; want %d", len(termNames), len(wantDirs))
+			}
+			for i, termName := range termNames {
+				term := inst.Terminals[termName]
+				if got, want := term.Outside[0].ERC.Dir, wantDirs[i]; got != want {
+					t.Errorf("terminal %s has direction %s; want %s", termName, got, want)
+				}
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package eval
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/cirbo-lang/cirbo/cbty"
 	"github.com/cirbo-lang/cirbo/source"
+	"github.com/cirbo-lang/cirbo/units"
 )
 
 type Expr struct {
@@ -416,7 +418,22 @@ func AttrExpr(obj Expr, name string, rng source.Range) Expr {
 }
 
 func (e *attrExpr) value(ctx *Context, targetSym *Symbol) (cbty.Value, source.Diags) {
-	panic("attrExpr.value not yet implemented")
+	obj, diags := e.obj.value(ctx, nil)
+	if obj.Type().Same(cbty.PlaceholderVal.Type()) {
+		return cbty.PlaceholderVal, diags
+	}
+
+	if !obj.Type().HasAttr(e.name) {
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Unsupported attribute",
+			Detail:  fmt.Sprintf("A value of type %s does not have an attribute named %q.", obj.Type().Name(), e.name),
+			Ranges:  e.sourceRange().List(),
+		})
+		return cbty.PlaceholderVal, diags
+	}
+
+	return obj.GetAttr(e.name), diags
 }
 
 func (e *attrExpr) eachChild(cb walkCb) {
@@ -473,3 +490,212 @@ func (e *passthroughExpr) eachChild(cb walkCb) {
 func (e *passthroughExpr) GoString() string {
 	return fmt.Sprintf("eval.PassthroughExpr(%#v)", e.expr)
 }
+
+type conversionExpr struct {
+	expr Expr
+	unit Expr
+	rng
+}
+
+// ConversionExpr returns an expression that re-expresses the result of the
+// given expression in the unit produced by the given unit expression, which
+// must produce a value of type cbty.Unit.
+//
+// The result has the same type as the given expression. If the expression's
+// values cannot be expressed in the given unit then evaluation produces
+// an error diagnostic.
+func ConversionExpr(expr Expr, unit Expr, rng source.Range) Expr {
+	return Expr{&conversionExpr{
+		expr: expr,
+		unit: unit,
+		rng:  srcRange(rng),
+	}}
+}
+
+func (e *conversionExpr) value(ctx *Context, targetSym *Symbol) (cbty.Value, source.Diags) {
+	val, diags := e.expr.value(ctx, nil)
+	unitVal, unitDiags := unitValue(ctx, e.unit)
+	diags = append(diags, unitDiags...)
+	if unitVal.IsUnknown() {
+		return cbty.PlaceholderVal, diags
+	}
+	unit := unitVal.AsUnit()
+	wantTy := cbty.UnitType(unit)
+	if val.Type().IsComplex() && !unit.Logarithmic() && !unit.OffsetTemperature() {
+		wantTy = cbty.ComplexQuantity(unit.Dimensionality())
+	}
+
+	switch {
+	case val.Type().Same(cbty.PlaceholderVal.Type()):
+		// Placeholder values are produced after errors that have already
+		// been reported, so we'll just pass through the expected type.
+		return cbty.UnknownVal(wantTy), diags
+	case !val.Type().IsNumber() && !val.Type().IsComplex():
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Invalid operand type",
+			Detail:  fmt.Sprintf("Cannot convert a %s value to a unit.", val.Type().Name()),
+			Ranges:  e.sourceRange().List(),
+		})
+		return cbty.UnknownVal(wantTy), diags
+	case !val.Type().Same(wantTy):
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Incompatible unit",
+			Detail:  fmt.Sprintf("A %s value cannot be expressed in %s, which is a unit of %s.", val.Type().Name(), unit, wantTy.Name()),
+			Ranges:  e.sourceRange().List(),
+		})
+		return cbty.UnknownVal(wantTy), diags
+	}
+
+	return val.ConvertUnit(unit), diags
+}
+
+func (e *conversionExpr) eachChild(cb walkCb) {
+	cb(e.expr)
+	cb(e.unit)
+}
+
+func (e *conversionExpr) GoString() string {
+	return fmt.Sprintf("eval.ConversionExpr(%#v, %#v)", e.expr, e.unit)
+}
+
+type quantityExpr struct {
+	num  *big.Float
+	unit Expr
+	rng
+}
+
+// QuantityExpr returns an expression that produces a quantity of the given
+// value in the unit produced by the given unit expression, which must produce
+// a value of type cbty.Unit. This is used for number literals whose unit is
+// declared in a package, rather than built in.
+func QuantityExpr(value *big.Float, unit Expr, rng source.Range) Expr {
+	return Expr{&quantityExpr{
+		num:  value,
+		unit: unit,
+		rng:  srcRange(rng),
+	}}
+}
+
+func (e *quantityExpr) value(ctx *Context, targetSym *Symbol) (cbty.Value, source.Diags) {
+	unitVal, diags := unitValue(ctx, e.unit)
+	if unitVal.IsUnknown() {
+		return cbty.PlaceholderVal, diags
+	}
+	return cbty.QuantityVal(units.MakeQuantity(e.num, unitVal.AsUnit())), diags
+}
+
+func (e *quantityExpr) eachChild(cb walkCb) {
+	cb(e.unit)
+}
+
+func (e *quantityExpr) GoString() string {
+	return fmt.Sprintf("eval.QuantityExpr(%s, %#v)", e.num, e.unit)
+}
+
+type toleranceExpr struct {
+	nom, minus, plus Expr
+	relative         bool
+	rng
+}
+
+// ToleranceExpr returns an expression that applies a tolerance to the
+// quantity produced by the given nominal expression, as given in a number
+// literal.
+//
+// If relative is true then the minus and plus expressions must produce
+// dimensionless numbers giving the tolerance as a fraction of the nominal
+// value. Otherwise, they must produce quantities commensurable with the
+// nominal value, with any absolute temperatures taken as differences, or
+// gains in dB if the nominal value is a level.
+func ToleranceExpr(nom, minus, plus Expr, relative bool, rng source.Range) Expr {
+	return Expr{&toleranceExpr{
+		nom:      nom,
+		minus:    minus,
+		plus:     plus,
+		relative: relative,
+		rng:      srcRange(rng),
+	}}
+}
+
+func (e *toleranceExpr) value(ctx *Context, targetSym *Symbol) (cbty.Value, source.Diags) {
+	var diags source.Diags
+	nomVal, nomDiags := e.nom.value(ctx, nil)
+	diags = append(diags, nomDiags...)
+	minusVal, minusDiags := e.minus.value(ctx, nil)
+	diags = append(diags, minusDiags...)
+	plusVal, plusDiags := e.plus.value(ctx, nil)
+	diags = append(diags, plusDiags...)
+
+	for _, val := range []cbty.Value{nomVal, minusVal, plusVal} {
+		if !val.Type().IsNumber() || val.IsUnknown() {
+			// Our operands are all literals, so this can only be the
+			// result of an error that has already been reported.
+			return cbty.PlaceholderVal, diags
+		}
+	}
+
+	nom := nomVal.AsQuantity()
+	if e.relative {
+		tq := nom.WithTolerance(minusVal.AsQuantity().Value(), plusVal.AsQuantity().Value())
+		return cbty.TolerancedQuantityVal(tq), diags
+	}
+
+	minus := minusVal.AsQuantity().AsDifference()
+	plus := plusVal.AsQuantity().AsDifference()
+	// A level, such as 10dBm, can also be toleranced by a gain, such as 1dB,
+	// in the same way that a gain can be added to it.
+	gain := nom.Unit().Logarithmic() && minus.Unit().Logarithmic() && minus.Unit().Dimensionality() == units.Dimensionality{}
+	if !nom.CommensurableWith(minus) && !gain {
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Invalid tolerance",
+			Detail:  fmt.Sprintf("A tolerance in %s cannot apply to a value in %s. Give the tolerance as a percentage or in a unit of the same dimensionality as the value.", unitDesc(minus.Unit()), unitDesc(nom.Unit())),
+			Ranges:  e.sourceRange().List(),
+		})
+		return cbty.PlaceholderVal, diags
+	}
+
+	tq := units.MakeTolerancedQuantity(nom.Subtract(minus), nom, nom.Add(plus))
+	return cbty.TolerancedQuantityVal(tq), diags
+}
+
+func (e *toleranceExpr) eachChild(cb walkCb) {
+	cb(e.nom)
+	cb(e.minus)
+	cb(e.plus)
+}
+
+func (e *toleranceExpr) GoString() string {
+	return fmt.Sprintf("eval.ToleranceExpr(%#v, %#v, %#v, %#v)", e.nom, e.minus, e.plus, e.relative)
+}
+
+// unitDesc returns a description of the given unit for use in diagnostics.
+func unitDesc(unit *units.Unit) string {
+	if name := unit.String(); name != "" {
+		return name
+	}
+	return "dimensionless units"
+}
+
+// unitValue evaluates the given expression, which is expected to produce a
+// value of type cbty.Unit. If it produces a value of any other type then the
+// result is unknown and an error diagnostic is returned.
+func unitValue(ctx *Context, expr Expr) (cbty.Value, source.Diags) {
+	val, diags := expr.value(ctx, nil)
+	switch {
+	case val.Type().Same(cbty.Unit):
+		return val, diags
+	case val.Type().Same(cbty.PlaceholderVal.Type()):
+		return cbty.UnknownVal(cbty.Unit), diags
+	default:
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Invalid unit",
+			Detail:  fmt.Sprintf("Expected a unit, but this is a %s value.", val.Type().Name()),
+			Ranges:  expr.sourceRange().List(),
+		})
+		return cbty.UnknownVal(cbty.Unit), diags
+	}
+}
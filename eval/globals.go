@@ -40,4 +40,13 @@ func init() {
 		globalScope.symbols[name] = sym
 		globalContext.values[sym] = val
 	}
+
+	for name, dev := range intrinsicDevices() {
+		sym := &Symbol{
+			scope: globalScope,
+			name:  name,
+		}
+		globalScope.symbols[name] = sym
+		globalContext.values[sym] = deviceValue(dev)
+	}
 }
@@ -14,11 +14,18 @@ type device struct {
 	attrs   StmtBlockAttrs
 	block   StmtBlock
 	instTy  cbty.Type
+
+	// terminals and ercOnly are currently used only for intrinsic devices,
+	// which are implemented in Go rather than declared in the language.
+	terminals cbo.TerminalsDef
+	ercOnly   bool
 }
 
 func (dev *device) AsPublic() *cbo.Device {
 	ret := &cbo.Device{}
 	ret.Name = dev.name
+	ret.Terminals = dev.terminals
+	ret.ERCOnly = dev.ercOnly
 	ret.Attrs = cbo.AttributesDef{}
 	for name, attr := range dev.attrs {
 		reqd := false
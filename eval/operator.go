@@ -58,19 +58,42 @@ func (o operator) evalBinary(ctx *Context, lhs, rhs Expr, rng source.Range) (cbt
 	}
 
 	switch o {
-	case opAdd, opSubtract:
+	case opAdd, opSubtract, opMultiply, opDivide:
+		// Complex quantities cannot have tolerances, so mixing in a
+		// toleranced quantity would silently discard its range.
+		if (lv.Type().IsComplex() || rv.Type().IsComplex()) && (lv.IsToleranced() || rv.IsToleranced()) {
+			diags = append(diags, source.Diag{
+				Level:   source.Error,
+				Summary: "Invalid operand types",
+				Detail:  fmt.Sprintf("Cannot %s with a toleranced quantity and a complex quantity, because complex quantities cannot have tolerances.", o.verb()),
+				Ranges:  rng.List(),
+			})
+			return cbty.PlaceholderVal, diags
+		}
+		if problem := o.temperatureProblem(lv, rv); problem != "" {
+			diags = append(diags, source.Diag{
+				Level:   source.Error,
+				Summary: "Invalid operand types",
+				Detail:  problem,
+				Ranges:  rng.List(),
+			})
+			return cbty.PlaceholderVal, diags
+		}
+	}
+
+	switch o {
+	case opAdd:
 		if !lv.Type().CanSum(rv.Type()) {
 			diags = append(diags, invalidTypes())
 			return cbty.PlaceholderVal, diags
 		}
-		switch o {
-		case opAdd:
-			return lv.Add(rv), diags
-		case opSubtract:
-			return lv.Subtract(rv), diags
-		default:
-			panic("invalid sum operator")
+		return lv.Add(rv), diags
+	case opSubtract:
+		if !lv.Type().CanSubtract(rv.Type()) {
+			diags = append(diags, invalidTypes())
+			return cbty.PlaceholderVal, diags
 		}
+		return lv.Subtract(rv), diags
 	case opMultiply, opDivide, opModulo, opExponent:
 		if !lv.Type().CanProduct(rv.Type()) {
 			diags = append(diags, invalidTypes())
@@ -80,6 +103,15 @@ func (o operator) evalBinary(ctx *Context, lhs, rhs Expr, rng source.Range) (cbt
 		case opMultiply:
 			return lv.Multiply(rv), diags
 		case opDivide:
+			if problem := divisionProblem(lv, rv); problem != "" {
+				diags = append(diags, source.Diag{
+					Level:   source.Error,
+					Summary: "Invalid division",
+					Detail:  problem,
+					Ranges:  rng.List(),
+				})
+				return lv.Divide(cbty.UnknownVal(rv.Type())), diags
+			}
 			return lv.Divide(rv), diags
 		case opModulo:
 			// TODO: implement
@@ -120,6 +152,59 @@ func (o operator) evalBinary(ctx *Context, lhs, rhs Expr, rng source.Range) (cbt
 	}
 }
 
+// temperatureProblem returns a message explaining why the receiver cannot
+// apply to the given values because of an absolute temperature in a unit
+// whose zero is not absolute zero, such as degrees Celsius, or an empty
+// string if there is no such problem.
+//
+// Such a temperature can only be offset by a temperature difference, or
+// subtracted from another absolute temperature to find the difference.
+func (o operator) temperatureProblem(lv, rv cbty.Value) string {
+	lAbs, rAbs := offsetTemperature(lv), offsetTemperature(rv)
+	switch {
+	case o == opAdd && lAbs && rAbs:
+		return "Cannot add two absolute temperatures. To offset a temperature, give the offset in kelvins."
+	case (o == opMultiply || o == opDivide) && (lAbs || rAbs):
+		return fmt.Sprintf("Cannot %s an absolute temperature in a unit whose zero is not absolute zero. Convert it to kelvins first, or subtract another temperature to find a difference.", o.verb())
+	}
+	return ""
+}
+
+// offsetTemperature returns true if the given value is a known absolute
+// temperature in a unit whose zero is not absolute zero.
+func offsetTemperature(v cbty.Value) bool {
+	if v.IsUnknown() || !v.Type().Same(cbty.Temperature) {
+		return false
+	}
+	return v.AsTolerancedQuantity().Unit().OffsetTemperature()
+}
+
+// divisionProblem returns a message explaining why the first given value
+// cannot be divided by the second, or an empty string if the division can
+// proceed.
+//
+// Dividing a real quantity by an exact zero produces an infinity, but
+// complex quantities have no infinity, and dividing by a toleranced quantity
+// whose range includes zero produces a range that is unbounded, neither of
+// which can be represented.
+func divisionProblem(lv, rv cbty.Value) string {
+	switch {
+	case rv.IsUnknown():
+		return ""
+	case lv.Type().IsComplex() || rv.Type().IsComplex():
+		c := rv.AsComplexQuantity()
+		if c.Real().Value().Sign() == 0 && c.Imag().Value().Sign() == 0 {
+			return "Cannot divide by a complex quantity of zero."
+		}
+	case lv.IsToleranced() || rv.IsToleranced():
+		tq := rv.AsTolerancedQuantity()
+		if tq.Min().Value().Sign() <= 0 && tq.Max().Value().Sign() >= 0 {
+			return fmt.Sprintf("Cannot divide by %s, because its range includes zero.", tq)
+		}
+	}
+	return ""
+}
+
 func (o operator) evalUnary(ctx *Context, val Expr, rng source.Range) (cbty.Value, source.Diags) {
 	vv, diags := val.value(ctx, nil)
 
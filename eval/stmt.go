@@ -6,6 +6,7 @@ import (
 
 	"github.com/cirbo-lang/cirbo/cbty"
 	"github.com/cirbo-lang/cirbo/source"
+	"github.com/cirbo-lang/cirbo/units"
 )
 
 type Stmt struct {
@@ -123,6 +124,129 @@ func (s *exportStmt) requiredSymbols(scope *Scope) SymbolSet {
 	return s.value.RequiredSymbols(scope)
 }
 
+type unitStmt struct {
+	sym  *Symbol
+	expr Expr
+	rng
+}
+
+// UnitStmt returns a statement that defines the given symbol as a unit whose
+// magnitude is the quantity given by the expression, or as an alias of an
+// existing unit if the expression produces a unit.
+func UnitStmt(sym *Symbol, expr Expr, rng source.Range) Stmt {
+	return Stmt{&unitStmt{
+		sym:  sym,
+		expr: expr,
+		rng:  srcRange(rng),
+	}}
+}
+
+func (s *unitStmt) definedSymbol() *Symbol {
+	return s.sym
+}
+
+func (s *unitStmt) requiredSymbols(scope *Scope) SymbolSet {
+	return s.expr.RequiredSymbols(scope)
+}
+
+func (s *unitStmt) execute(exec *StmtBlockExecute, result *StmtBlockResult) source.Diags {
+	val, diags := s.expr.Value(exec.Context)
+	unit := cbty.UnknownVal(cbty.Unit)
+
+	switch {
+	case val.Type().Same(cbty.Unit):
+		unit = val
+	case val.Type().Same(cbty.PlaceholderVal.Type()):
+		// Errors were already reported while evaluating the expression.
+	case !val.Type().IsNumber():
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Invalid unit definition",
+			Detail:  fmt.Sprintf("A unit must be defined as a quantity, such as 34.79um, or as another unit, not as a %s value.", val.Type().Name()),
+			Ranges:  s.expr.sourceRange().List(),
+		})
+	case val.IsUnknown():
+		// Unknown definitions produce unknown units.
+	case val.IsToleranced():
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Invalid unit definition",
+			Detail:  "A unit must be defined as an exact quantity, without a tolerance.",
+			Ranges:  s.expr.sourceRange().List(),
+		})
+	default:
+		u, err := units.DefineUnit(s.sym.DeclaredName(), val.AsQuantity())
+		if err != nil {
+			diags = append(diags, source.Diag{
+				Level:   source.Error,
+				Summary: "Invalid unit definition",
+				Detail:  fmt.Sprintf("Cannot define %s as %s: %s.", s.sym.DeclaredName(), val.AsQuantity(), err),
+				Ranges:  s.expr.sourceRange().List(),
+			})
+			break
+		}
+		unit = cbty.UnitVal(u)
+	}
+
+	exec.Context.DefineLiteral(s.sym, unit)
+	return diags
+}
+
+type dimensionStmt struct {
+	sym  *Symbol
+	expr Expr
+	rng
+}
+
+// DimensionStmt returns a statement that defines the given symbol as a named
+// quantity type, whose dimensionality is given either by a quantity or by
+// an existing number type produced by the expression.
+func DimensionStmt(sym *Symbol, expr Expr, rng source.Range) Stmt {
+	return Stmt{&dimensionStmt{
+		sym:  sym,
+		expr: expr,
+		rng:  srcRange(rng),
+	}}
+}
+
+func (s *dimensionStmt) definedSymbol() *Symbol {
+	return s.sym
+}
+
+func (s *dimensionStmt) requiredSymbols(scope *Scope) SymbolSet {
+	return s.expr.RequiredSymbols(scope)
+}
+
+func (s *dimensionStmt) execute(exec *StmtBlockExecute, result *StmtBlockResult) source.Diags {
+	val, diags := s.expr.Value(exec.Context)
+
+	ty := val.Type()
+	if ty.Same(cbty.TypeType) && val.IsKnown() {
+		ty = val.UnwrapType()
+	}
+
+	switch {
+	case ty.Same(cbty.PlaceholderVal.Type()) || (ty.Same(cbty.TypeType) && val.IsUnknown()):
+		// We can't know the dimensionality, but we do know that the result
+		// will be a type.
+		exec.Context.DefineLiteral(s.sym, cbty.UnknownVal(cbty.TypeType))
+		return diags
+	case !ty.IsNumber() || ty.IsLevel():
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Invalid dimension definition",
+			Detail:  fmt.Sprintf("A dimension must be defined as a quantity, such as 1W / 1K, or as a quantity type, not as %s.", ty.Name()),
+			Ranges:  s.expr.sourceRange().List(),
+		})
+		exec.Context.DefineLiteral(s.sym, cbty.UnknownVal(cbty.TypeType))
+		return diags
+	}
+
+	named := cbty.NamedQuantity(s.sym.DeclaredName(), ty.NumberDimensionality())
+	exec.Context.DefineLiteral(s.sym, cbty.TypeTypeVal(named))
+	return diags
+}
+
 type attrStmt struct {
 	sym *Symbol
 
@@ -1,11 +1,13 @@
 package eval
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/cirbo-lang/cirbo/cbty"
 	"github.com/cirbo-lang/cirbo/source"
+	"github.com/cirbo-lang/cirbo/units"
 )
 
 func TestNewStmtBlock(t *testing.T) {
@@ -261,6 +263,102 @@ func TestStmtBlockAttributes(t *testing.T) {
 	}
 }
 
+func TestStmtBlockUnits(t *testing.T) {
+	type testCase struct {
+		Stmt  Stmt
+		Sym   *Symbol
+		Want  string
+		Diags int
+	}
+
+	tests := map[string]func(scope *Scope) testCase{
+		"unit": func(scope *Scope) testCase {
+			sym := scope.Declare("oz_cu")
+			expr := LiteralExpr(cbty.QuantityVal(units.MakeQuantityFloat(34.79, units.ByName("um"))), source.NilRange)
+			return testCase{
+				Stmt:  UnitStmt(sym, expr, source.NilRange),
+				Sym:   sym,
+				Want:  `cty.UnitVal(&units.Unit{dim: units.Dimensionality{Mass:0, Length:1, Angle:0, Time:0, ElectricCurrent:0, LuminousIntensity:0, Temperature:0}, scale: 0, name: "oz_cu"})`,
+				Diags: 0,
+			}
+		},
+		"unit alias": func(scope *Scope) testCase {
+			sym := scope.Declare("thou")
+			expr := LiteralExpr(cbty.UnitVal(units.ByName("mil")), source.NilRange)
+			return testCase{
+				Stmt:  UnitStmt(sym, expr, source.NilRange),
+				Sym:   sym,
+				Want:  `cty.UnitVal(units.ByName("mil"))`,
+				Diags: 0,
+			}
+		},
+		"unit with tolerance": func(scope *Scope) testCase {
+			sym := scope.Declare("oz_cu")
+			expr := LiteralExpr(cbty.TolerancedQuantityVal(units.MakeTolerancedQuantity(
+				units.MakeQuantityInt(34, units.ByName("um")),
+				units.MakeQuantityInt(35, units.ByName("um")),
+				units.MakeQuantityInt(36, units.ByName("um")),
+			)), source.NilRange)
+			return testCase{
+				Stmt:  UnitStmt(sym, expr, source.NilRange),
+				Sym:   sym,
+				Want:  `cty.UnknownVal(cty.Unit)`,
+				Diags: 1, // toleranced quantities cannot define units
+			}
+		},
+		"unit from string": func(scope *Scope) testCase {
+			sym := scope.Declare("oz_cu")
+			expr := LiteralExpr(cbty.StringVal("34.79um"), source.NilRange)
+			return testCase{
+				Stmt:  UnitStmt(sym, expr, source.NilRange),
+				Sym:   sym,
+				Want:  `cty.UnknownVal(cty.Unit)`,
+				Diags: 1, // strings cannot define units
+			}
+		},
+		"dimension": func(scope *Scope) testCase {
+			sym := scope.Declare("Thickness")
+			expr := SymbolExpr(scope.Get("Length"), source.NilRange)
+			return testCase{
+				Stmt:  DimensionStmt(sym, expr, source.NilRange),
+				Sym:   sym,
+				Want:  `cty.TypeTypeVal(cty.NamedQuantity("Thickness", units.Dimensionality{Mass:0, Length:1, Angle:0, Time:0, ElectricCurrent:0, LuminousIntensity:0, Temperature:0}))`,
+				Diags: 0,
+			}
+		},
+		"dimension from string": func(scope *Scope) testCase {
+			sym := scope.Declare("Thickness")
+			expr := LiteralExpr(cbty.StringVal("Length"), source.NilRange)
+			return testCase{
+				Stmt:  DimensionStmt(sym, expr, source.NilRange),
+				Sym:   sym,
+				Want:  `cty.UnknownVal(cty.Type)`,
+				Diags: 1, // strings cannot define dimensions
+			}
+		},
+	}
+
+	for name, cons := range tests {
+		t.Run(name, func(t *testing.T) {
+			scope := globalScope.NewChild()
+			test := cons(scope)
+			block, diags := MakeStmtBlock(scope, []Stmt{test.Stmt})
+
+			result, execDiags := block.Execute(StmtBlockExecute{
+				Context: globalContext,
+			}, nil)
+			diags = append(diags, execDiags...)
+
+			assertDiagCount(t, diags, test.Diags)
+
+			got := fmt.Sprintf("%#v", result.Context.Value(test.Sym))
+			if got != test.Want {
+				t.Fatalf("wrong result\ngot:  %s\nwant: %s", got, test.Want)
+			}
+		})
+	}
+}
+
 type mockStmt struct {
 	defines  *Symbol
 	requires SymbolSet
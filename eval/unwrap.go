@@ -83,6 +83,13 @@ func (u *Unwrapper) unwrapModel(raw interface{}) cbo.Any {
 			val := tv.content.Context.Value(attr.Symbol)
 			ret.Attrs[name] = u.Unwrap(val)
 		}
+		if terms := ret.Device.Terminals.All; len(terms) > 0 {
+			ret.Terminals = make(map[string]*cbo.TerminalInstance, len(terms))
+			for name := range terms {
+				term := terms[name]
+				ret.Terminals[name] = term.NewInstance()
+			}
+		}
 		if u.deviceInstances == nil {
 			u.deviceInstances = map[*deviceInstance]*cbo.DeviceInstance{}
 		}
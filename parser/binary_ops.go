@@ -36,7 +36,8 @@ var binaryOps = []map[TokenType]ast.ArithmeticOp{
 	{
 		TokenStar:  ast.Multiply,
 		TokenSlash: ast.Divide,
-		// TODO: Modulo? It doesn't have a punctuation token associated with
-		// it so it doesn't really fit into this model.
+		// TODO: TokenModulo: ast.Modulo, once the evaluator implements
+		// modulo. (A percent sign directly after a number literal is instead
+		// TokenPercent, making the number a percentage.)
 	},
 }
@@ -17,6 +17,14 @@ import (
 
 var oneHundred = mustParseBigFloat("100")
 
+// ratioKeywords are the identifiers that can follow a number literal, like
+// a percent sign, to give the number as a ratio. Each maps to the value
+// that the number is divided by.
+var ratioKeywords = map[string]*big.Float{
+	"ppm": mustParseBigFloat("1e6"),
+	"ppb": mustParseBigFloat("1e9"),
+}
+
 type Parser struct {
 	files map[projpath.FilePath]*ast.File
 	diags source.Diags
@@ -129,6 +137,11 @@ func ParseExpr(src []byte) (ast.Node, source.Diags) {
 type parser struct {
 	tokenPeeker
 	recovering bool
+
+	// exportValue is true while parsing the top level of the value in an
+	// export statement, where "as" followed by a name that is not a
+	// built-in unit gives the export name rather than a unit conversion.
+	exportValue bool
 }
 
 func (p *parser) ParseTopLevel() ([]ast.Node, source.Range, source.Diags) {
@@ -212,6 +225,15 @@ Statements:
 		case "pinout":
 			node, nodeDiags = p.parsePinout()
 
+		case "unit", "dimension":
+			// These are keywords only when followed by the name being
+			// declared, so that they can still be used as symbol names.
+			if p.PeekKeywordAfter() == "" {
+				node, nodeDiags = p.parseAssignOrConnectStmt()
+				break
+			}
+			node, nodeDiags = p.parseUnitOrDimensionDecl()
+
 		default:
 
 			if p.keywordCanStartTerminalDecl(nextKw) {
@@ -669,7 +691,9 @@ func (p *parser) parseExport() (ast.Node, source.Diags) {
 		},
 	}
 
+	p.exportValue = true
 	export.Value, diags = p.parseExpr()
+	p.exportValue = false
 	export.Range = source.RangeBetween(kw.Range, export.Value.SourceRange())
 	if diags.HasErrors() {
 		p.recoverAfterSemicolon()
@@ -850,6 +874,78 @@ func (p *parser) parseAttr() (ast.Node, source.Diags) {
 	return attr, diags
 }
 
+// parseUnitOrDimensionDecl parses either a "unit" or a "dimension" statement,
+// which share the same syntax: the keyword, the name being declared, and
+// then its definition following an equals sign.
+func (p *parser) parseUnitOrDimensionDecl() (ast.Node, source.Diags) {
+	kw := p.Read()
+	if kw.Type != TokenIdent {
+		// Should never happen because caller should've peeked ahead here
+		panic("parseUnitOrDimensionDecl called with peeker not pointing at ident")
+	}
+	kwName := string(kw.Bytes)
+
+	var diags source.Diags
+
+	// Caller should've already checked that an identifier follows the
+	// keyword, so we can safely read it here.
+	nameTok := p.Read()
+	name := p.decodeIdentifierBytes(nameTok.Bytes)
+
+	if p.Peek().Type != TokenAssign {
+		if !p.recovering {
+			diags = append(diags, source.Diag{
+				Level:   source.Error,
+				Summary: "Missing definition",
+				Detail:  fmt.Sprintf("The name in a %q statement must be followed by \"=\" and its definition.", kwName),
+				Ranges:  []source.Range{p.PeekRange()},
+			})
+		}
+		p.recoverAfterSemicolon()
+		return nil, diags
+	}
+	p.Read() // eat assignment "="
+
+	value, diags := p.parseExpr()
+	rng := source.RangeBetween(kw.Range, value.SourceRange())
+	if diags.HasErrors() {
+		p.recoverAfterSemicolon()
+	} else if p.Peek().Type != TokenSemicolon {
+		if !p.recovering {
+			diags = append(diags, source.Diag{
+				Level:   source.Error,
+				Summary: "Unterminated statement",
+				Detail:  fmt.Sprintf("This %q statement must be terminated by a semicolon.", kwName),
+				Ranges:  rng.List(),
+			})
+		}
+		p.recoverAfterSemicolon()
+		return nil, diags
+	} else {
+		semicolon := p.Read()
+		rng = source.RangeBetween(kw.Range, semicolon.Range)
+	}
+
+	if kwName == "dimension" {
+		return &ast.DimensionDecl{
+			Name:  name,
+			Value: value,
+
+			WithRange: ast.WithRange{
+				Range: rng,
+			},
+		}, diags
+	}
+	return &ast.UnitDecl{
+		Name:  name,
+		Value: value,
+
+		WithRange: ast.WithRange{
+			Range: rng,
+		},
+	}, diags
+}
+
 func (p *parser) keywordCanStartTerminalDecl(kw string) bool {
 	switch kw {
 	case "terminal", "power", "input", "output", "bidi":
@@ -1542,6 +1638,29 @@ Trailers:
 				},
 			}
 
+		case TokenIdent:
+			// "as" followed by a unit name is a unit conversion.
+			if p.PeekKeyword() != "as" {
+				break Trailers
+			}
+			unitName := p.PeekKeywordAfter()
+			if unitName == "" || (p.exportValue && !ast.IsQuantityUnitKeyword(unitName)) {
+				break Trailers
+			}
+
+			p.Read() // eat the "as" keyword
+			unitTok := p.Read()
+
+			term = &ast.Conversion{
+				Operand:   term,
+				Unit:      string(unitTok.Bytes),
+				UnitRange: unitTok.Range,
+
+				WithRange: ast.WithRange{
+					Range: source.RangeBetween(term.SourceRange(), unitTok.Range),
+				},
+			}
+
 		default:
 			break Trailers
 
@@ -1558,7 +1677,11 @@ func (p *parser) parseExpressionTerm() (ast.Node, source.Diags) {
 	case TokenOParen:
 		open := p.Read()
 
+		// Within parentheses, "as" can only be a unit conversion.
+		exportValue := p.exportValue
+		p.exportValue = false
 		expr, diags := p.parseExpr()
+		p.exportValue = exportValue
 		close := p.Peek()
 		if close.Type != TokenCParen && !p.recovering {
 			diags = append(diags, source.Diag{
@@ -1631,39 +1754,60 @@ func (p *parser) parseExpressionTerm() (ast.Node, source.Diags) {
 		tok := p.Read()
 		val, diags := p.decodeNumberLiteral(tok)
 
+		lit := &ast.NumberLit{
+			WithRange: ast.WithRange{
+				Range: tok.Range,
+			},
+			Value: val,
+		}
+
 		next := p.Peek()
-		switch next.Type {
-		case TokenPercent:
+		switch div := p.peekRatio(); {
+		case div != nil:
 			marker := p.Read()
 			if val != nil {
-				val.Quo(val, oneHundred)
+				val.Quo(val, div)
 			}
-			return &ast.NumberLit{
-				WithRange: ast.WithRange{
-					Range: source.RangeBetween(tok.Range, marker.Range),
-				},
-				Value: val,
-			}, diags
-		case TokenIdent:
+			lit.Range = source.RangeBetween(tok.Range, marker.Range)
+		case next.Type == TokenIdent:
+			if p.PeekKeyword() == "j" && next.Range.Start.Byte == tok.Range.End.Byte {
+				// A j immediately following the number marks it as
+				// imaginary, as in 30j, and can then be followed by a
+				// built-in unit as in 30j ohm.
+				marker := p.Read()
+				lit.Range = source.RangeBetween(tok.Range, marker.Range)
+				lit.Imaginary = true
+				if p.Peek().Type == TokenIdent {
+					if kw := p.PeekKeyword(); ast.IsQuantityUnitKeyword(kw) {
+						marker := p.Read()
+						lit.Range = source.RangeBetween(tok.Range, marker.Range)
+						lit.Unit = kw
+					}
+				}
+				break
+			}
+
+			// Built-in units are recognized anywhere after the number, but
+			// any other identifier is taken as a unit only if it immediately
+			// follows the number, since it must then refer to a unit
+			// declared with a "unit" statement.
 			kw := p.PeekKeyword()
-			if ast.IsQuantityUnitKeyword(kw) {
+			adjacent := next.Range.Start.Byte == tok.Range.End.Byte
+			if ast.IsQuantityUnitKeyword(kw) || (kw != "" && adjacent) {
 				marker := p.Read()
-				return &ast.NumberLit{
-					WithRange: ast.WithRange{
-						Range: source.RangeBetween(tok.Range, marker.Range),
-					},
-					Value: val,
-					Unit:  kw,
-				}, diags
+				lit.Range = source.RangeBetween(tok.Range, marker.Range)
+				lit.Unit = kw
 			}
 		}
 
-		return &ast.NumberLit{
-			WithRange: ast.WithRange{
-				Range: tok.Range,
-			},
-			Value: val,
-		}, diags
+		if p.Peek().Type == TokenPlusMinus {
+			tol, end, tolDiags := p.parseTolerance()
+			diags = append(diags, tolDiags...)
+			lit.Tolerance = tol
+			lit.Range = source.RangeBetween(lit.Range, end)
+		}
+
+		return lit, diags
 
 	case TokenBang:
 		op := p.Read()
@@ -1715,6 +1859,165 @@ func (p *parser) parseExpressionTerm() (ast.Node, source.Diags) {
 	}
 }
 
+// parseTolerance parses the tolerance that may follow a number literal,
+// starting at its ± sign. A tolerance is either symmetric, such as ±1% or
+// ±10mV, or gives signed lower and upper bounds in either order, such as
+// ±(+80%, -20%).
+//
+// The returned range is that of the last token of the tolerance.
+func (p *parser) parseTolerance() (*ast.Tolerance, source.Range, source.Diags) {
+	marker := p.Read()
+	if marker.Type != TokenPlusMinus {
+		// should never happen
+		panic("parseTolerance called with peeker not at ±")
+	}
+
+	if p.Peek().Type != TokenOParen {
+		val, unit, relative, end, diags := p.parseToleranceBound()
+		return &ast.Tolerance{
+			Minus:    val,
+			Plus:     val,
+			Relative: relative,
+			Unit:     unit,
+		}, end, diags
+	}
+
+	open := p.Read()
+	tol := &ast.Tolerance{}
+	var diags source.Diags
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			if comma := p.Peek(); comma.Type != TokenComma {
+				diags = append(diags, source.Diag{
+					Level:   source.Error,
+					Summary: "Invalid tolerance",
+					Detail:  "Expected a comma between the bounds of the tolerance.",
+					Ranges:  comma.Range.List(),
+				})
+				close := p.recoverAfterClose(TokenCParen)
+				return tol, close.Range, diags
+			}
+			p.Read()
+		}
+
+		sign := p.Peek()
+		if sign.Type != TokenPlus && sign.Type != TokenMinus {
+			diags = append(diags, source.Diag{
+				Level:   source.Error,
+				Summary: "Invalid tolerance",
+				Detail:  "Each bound of an asymmetric tolerance must begin with a sign, as in ±(+80%, -20%).",
+				Ranges:  sign.Range.List(),
+			})
+			close := p.recoverAfterClose(TokenCParen)
+			return tol, close.Range, diags
+		}
+		p.Read()
+
+		val, unit, relative, end, boundDiags := p.parseToleranceBound()
+		diags = append(diags, boundDiags...)
+		if boundDiags.HasErrors() {
+			close := p.recoverAfterClose(TokenCParen)
+			return tol, close.Range, diags
+		}
+
+		boundRange := source.RangeBetween(sign.Range, end)
+		switch {
+		case sign.Type == TokenPlus && tol.Plus == nil:
+			tol.Plus = val
+		case sign.Type == TokenMinus && tol.Minus == nil:
+			tol.Minus = val
+		default:
+			diags = append(diags, source.Diag{
+				Level:   source.Error,
+				Summary: "Invalid tolerance",
+				Detail:  "An asymmetric tolerance must have one positive and one negative bound.",
+				Ranges:  boundRange.List(),
+			})
+		}
+
+		if i == 0 {
+			tol.Relative = relative
+			tol.Unit = unit
+		} else if relative != tol.Relative || unit != tol.Unit {
+			diags = append(diags, source.Diag{
+				Level:   source.Error,
+				Summary: "Invalid tolerance",
+				Detail:  "Both bounds of a tolerance must be percentages, or must both be given in the same unit.",
+				Ranges:  boundRange.List(),
+			})
+		}
+	}
+
+	close := p.Peek()
+	if close.Type != TokenCParen {
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Unbalanced parentheses",
+			Detail:  "Expected a closing parenthesis to terminate the tolerance.",
+			Ranges:  source.RangeBetween(open.Range, close.Range).List(),
+		})
+		close = p.recoverAfterClose(TokenCParen)
+		return tol, close.Range, diags
+	}
+	p.Read()
+
+	return tol, close.Range, diags
+}
+
+// parseToleranceBound parses a single bound of a tolerance, which is a number
+// followed by either a percent sign or a unit.
+func (p *parser) parseToleranceBound() (val *big.Float, unit string, relative bool, end source.Range, diags source.Diags) {
+	tok := p.Peek()
+	if tok.Type != TokenNumberLit {
+		diags = append(diags, source.Diag{
+			Level:   source.Error,
+			Summary: "Invalid tolerance",
+			Detail:  "Expected a tolerance, such as 1% or 10mV.",
+			Ranges:  tok.Range.List(),
+		})
+		p.setRecovering()
+		return nil, "", false, tok.Range, diags
+	}
+	p.Read()
+
+	val, diags = p.decodeNumberLiteral(tok)
+	end = tok.Range
+
+	if div := p.peekRatio(); div != nil {
+		end = p.Read().Range
+		val.Quo(val, div)
+		relative = true
+	} else if next := p.Peek(); next.Type == TokenIdent {
+		// As for the nominal value, a declared unit must immediately
+		// follow the number.
+		kw := p.PeekKeyword()
+		adjacent := next.Range.Start.Byte == tok.Range.End.Byte
+		if ast.IsQuantityUnitKeyword(kw) || (kw != "" && adjacent) {
+			end = p.Read().Range
+			unit = kw
+		}
+	}
+
+	return val, unit, relative, end, diags
+}
+
+// peekRatio returns the value to divide a number literal by if the next
+// token is a ratio suffix, such as % or ppm, or nil otherwise.
+//
+// The scanner produces TokenPercent only for a percent sign immediately
+// following a number literal, so a percent sign elsewhere is not mistaken
+// for a ratio suffix.
+func (p *parser) peekRatio() *big.Float {
+	switch p.Peek().Type {
+	case TokenPercent:
+		return oneHundred
+	case TokenIdent:
+		return ratioKeywords[p.PeekKeyword()]
+	default:
+		return nil
+	}
+}
+
 func (p *parser) parseParameters() (*ast.Arguments, source.Diags) {
 	// parseParameters raturns an ast.Arguments that meets the constraints for
 	// a parameter list: contains only positional arguments, and all of the
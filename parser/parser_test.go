@@ -187,6 +187,31 @@ func TestParseTopLevel(t *testing.T) {
 			},
 			0,
 		},
+		{
+			`export width as thing;`,
+			[]ast.Node{
+				&ast.Export{
+					Value: &ast.Variable{
+						Name: "width",
+
+						WithRange: ast.WithRange{
+							Range: source.Range{
+								Start: source.Pos{Line: 1, Column: 8, Byte: 7},
+								End:   source.Pos{Line: 1, Column: 13, Byte: 12},
+							},
+						},
+					},
+					Name: "thing",
+					WithRange: ast.WithRange{
+						Range: source.Range{
+							Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+							End:   source.Pos{Line: 1, Column: 23, Byte: 22},
+						},
+					},
+				},
+			},
+			0,
+		},
 
 		{
 			`designator "R";`,
@@ -314,6 +339,85 @@ func TestParseTopLevel(t *testing.T) {
 			},
 			0,
 		},
+		{
+			`unit oz_cu = 34.79um;`,
+			[]ast.Node{
+				&ast.UnitDecl{
+					Name: "oz_cu",
+					Value: &ast.NumberLit{
+						Value: mustParseBigFloat("34.79"),
+						Unit:  "um",
+
+						WithRange: ast.WithRange{
+							Range: source.Range{
+								Start: source.Pos{Line: 1, Column: 14, Byte: 13},
+								End:   source.Pos{Line: 1, Column: 21, Byte: 20},
+							},
+						},
+					},
+
+					WithRange: ast.WithRange{
+						Range: source.Range{
+							Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+							End:   source.Pos{Line: 1, Column: 22, Byte: 21},
+						},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`dimension Thickness = Length;`,
+			[]ast.Node{
+				&ast.DimensionDecl{
+					Name: "Thickness",
+					Value: &ast.Variable{
+						Name: "Length",
+
+						WithRange: ast.WithRange{
+							Range: source.Range{
+								Start: source.Pos{Line: 1, Column: 23, Byte: 22},
+								End:   source.Pos{Line: 1, Column: 29, Byte: 28},
+							},
+						},
+					},
+
+					WithRange: ast.WithRange{
+						Range: source.Range{
+							Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+							End:   source.Pos{Line: 1, Column: 30, Byte: 29},
+						},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`unit = true;`,
+			[]ast.Node{
+				&ast.Assign{
+					Name: "unit",
+					Value: &ast.BooleanLit{
+						Value: true,
+
+						WithRange: ast.WithRange{
+							Range: source.Range{
+								Start: source.Pos{Line: 1, Column: 8, Byte: 7},
+								End:   source.Pos{Line: 1, Column: 12, Byte: 11},
+							},
+						},
+					},
+
+					WithRange: ast.WithRange{
+						Range: source.Range{
+							Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+							End:   source.Pos{Line: 1, Column: 13, Byte: 12},
+						},
+					},
+				},
+			},
+			0,
+		},
 		{
 			`a = true indeed;`,
 			[]ast.Node{
@@ -2084,6 +2188,51 @@ func TestParseExpression(t *testing.T) {
 			},
 			0,
 		},
+		{
+			`20ppm`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("0.00002"),
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 6, Byte: 5},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`5 ppb`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("0.000000005"),
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 6, Byte: 5},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`32.768kHz ±20ppm`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("32.768"),
+				Unit:  "kHz",
+				Tolerance: &ast.Tolerance{
+					Minus:    mustParseBigFloat("0.00002"),
+					Plus:     mustParseBigFloat("0.00002"),
+					Relative: true,
+				},
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 17, Byte: 17},
+					},
+				},
+			},
+			0,
+		},
 		{
 			`1m`,
 			&ast.NumberLit{
@@ -2112,6 +2261,48 @@ func TestParseExpression(t *testing.T) {
 			},
 			0,
 		},
+		{
+			`100nF`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("100"),
+				Unit:  "nF",
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 6, Byte: 5},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`85degC`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("85"),
+				Unit:  "degC",
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 7, Byte: 6},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`10µs`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("10"),
+				Unit:  "µs",
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 5, Byte: 5},
+					},
+				},
+			},
+			0,
+		},
 		{
 			`1 ohm`,
 			&ast.NumberLit{
@@ -2126,8 +2317,223 @@ func TestParseExpression(t *testing.T) {
 			},
 			0,
 		},
+		{
+			`30j`,
+			&ast.NumberLit{
+				Value:     mustParseBigFloat("30"),
+				Imaginary: true,
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`30j ohm`,
+			&ast.NumberLit{
+				Value:     mustParseBigFloat("30"),
+				Unit:      "ohm",
+				Imaginary: true,
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 8, Byte: 7},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`10kohm ±1%`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("10"),
+				Unit:  "kohm",
+				Tolerance: &ast.Tolerance{
+					Minus:    mustParseBigFloat("0.01"),
+					Plus:     mustParseBigFloat("0.01"),
+					Relative: true,
+				},
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 11, Byte: 11},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`5V ±250mV`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("5"),
+				Unit:  "V",
+				Tolerance: &ast.Tolerance{
+					Minus: mustParseBigFloat("250"),
+					Plus:  mustParseBigFloat("250"),
+					Unit:  "mV",
+				},
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 10, Byte: 10},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`100nF ±(+80%, -20%)`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("100"),
+				Unit:  "nF",
+				Tolerance: &ast.Tolerance{
+					Minus:    mustParseBigFloat("0.2"),
+					Plus:     mustParseBigFloat("0.8"),
+					Relative: true,
+				},
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 20, Byte: 20},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`100nF ±(+80%, +20%)`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("100"),
+				Unit:  "nF",
+				Tolerance: &ast.Tolerance{
+					Plus:     mustParseBigFloat("0.8"),
+					Relative: true,
+				},
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 20, Byte: 20},
+					},
+				},
+			},
+			1, // two positive bounds
+		},
+		{
+			`(0 ±1)`,
+			&ast.ParenExpr{
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 7, Byte: 7},
+					},
+				},
+				Content: &ast.NumberLit{
+					Value: mustParseBigFloat("0"),
+					Tolerance: &ast.Tolerance{
+						Minus: mustParseBigFloat("1"),
+						Plus:  mustParseBigFloat("1"),
+					},
+					WithRange: ast.WithRange{
+						Range: source.Range{
+							Start: source.Pos{Line: 1, Column: 2, Byte: 1},
+							End:   source.Pos{Line: 1, Column: 6, Byte: 6},
+						},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`width as mil`,
+			&ast.Conversion{
+				Operand: &ast.Variable{
+					Name: "width",
+
+					WithRange: ast.WithRange{
+						Range: source.Range{
+							Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+							End:   source.Pos{Line: 1, Column: 6, Byte: 5},
+						},
+					},
+				},
+				Unit: "mil",
+				UnitRange: source.Range{
+					Start: source.Pos{Line: 1, Column: 10, Byte: 9},
+					End:   source.Pos{Line: 1, Column: 13, Byte: 12},
+				},
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 13, Byte: 12},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`2 * 5V as mV`,
+			&ast.ArithmeticBinary{
+				LHS: &ast.NumberLit{
+					Value: mustParseBigFloat("2"),
+					WithRange: ast.WithRange{
+						Range: source.Range{
+							Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+							End:   source.Pos{Line: 1, Column: 2, Byte: 1},
+						},
+					},
+				},
+				Op: ast.Multiply,
+				RHS: &ast.Conversion{
+					Operand: &ast.NumberLit{
+						Value: mustParseBigFloat("5"),
+						Unit:  "V",
+						WithRange: ast.WithRange{
+							Range: source.Range{
+								Start: source.Pos{Line: 1, Column: 5, Byte: 4},
+								End:   source.Pos{Line: 1, Column: 7, Byte: 6},
+							},
+						},
+					},
+					Unit: "mV",
+					UnitRange: source.Range{
+						Start: source.Pos{Line: 1, Column: 11, Byte: 10},
+						End:   source.Pos{Line: 1, Column: 13, Byte: 12},
+					},
+					WithRange: ast.WithRange{
+						Range: source.Range{
+							Start: source.Pos{Line: 1, Column: 5, Byte: 4},
+							End:   source.Pos{Line: 1, Column: 13, Byte: 12},
+						},
+					},
+				},
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 13, Byte: 12},
+					},
+				},
+			},
+			0,
+		},
 		{
 			`1nonunit`,
+			&ast.NumberLit{
+				Value: mustParseBigFloat("1"),
+				Unit:  "nonunit", // might be a unit declared in the package
+				WithRange: ast.WithRange{
+					Range: source.Range{
+						Start: source.Pos{Line: 1, Column: 1, Byte: 0},
+						End:   source.Pos{Line: 1, Column: 9, Byte: 8},
+					},
+				},
+			},
+			0,
+		},
+		{
+			`1 nonunit`,
 			&ast.NumberLit{
 				Value: mustParseBigFloat("1"),
 				WithRange: ast.WithRange{
@@ -38,6 +38,24 @@ func (p *tokenPeeker) PeekKeyword() string {
 	return got
 }
 
+// PeekKeywordAfter is like PeekKeyword but checks the token after the next
+// token, without consuming either of them. This allows a keyword to be
+// recognized only when it is followed by some other keyword.
+func (p *tokenPeeker) PeekKeywordAfter() string {
+	p.Peek() // ensure that the next token is already peeked
+	for i := p.Iter.Pos; i < len(p.Iter.Tokens); i++ {
+		tok := p.Iter.Tokens[i]
+		if tok.Type == TokenWhitespace || tok.Type == TokenComment {
+			continue
+		}
+		if tok.Type != TokenIdent || tok.Bytes[0] == '`' {
+			return ""
+		}
+		return string(tok.Bytes)
+	}
+	return ""
+}
+
 func (p *tokenPeeker) PeekRange() source.Range {
 	return p.Peek().Range
 }
@@ -20,7 +20,7 @@ var _cirbotok_actions []byte = []byte{
 	1, 33, 1, 34, 1, 35, 1, 36,
 	1, 37, 1, 38, 2, 2, 3, 2,
 	2, 4, 2, 2, 5, 2, 2, 6,
-	2, 2, 7,
+	2, 2, 7, 1, 39,
 }
 
 var _cirbotok_key_offsets []int16 = []int16{
@@ -109,11 +109,11 @@ var _cirbotok_key_offsets []int16 = []int16{
 	4241, 4248, 4252, 4260, 4264, 4344, 4348, 4352,
 	4353, 4367, 4368, 4370, 4372, 4375, 4376, 4377,
 	4379, 4380, 4385, 4387, 4388, 4390, 4436, 4447,
-	4449, 4489, 4491, 4496, 4500, 4500, 4502, 4504,
-	4515, 4525, 4533, 4534, 4536, 4537, 4541, 4545,
-	4555, 4559, 4566, 4577, 4584, 4588, 4594, 4605,
-	4637, 4686, 4701, 4716, 4721, 4723, 4728, 4760,
-	4768, 4770, 4792, 4814,
+	4449, 4489, 4491, 4497, 4501, 4501, 4503, 4505,
+	4516, 4526, 4534, 4535, 4537, 4538, 4542, 4546,
+	4556, 4560, 4567, 4578, 4585, 4589, 4595, 4606,
+	4638, 4687, 4702, 4717, 4722, 4724, 4729, 4761,
+	4769, 4771, 4793, 4815,
 }
 
 var _cirbotok_trans_keys []byte = []byte{
@@ -678,47 +678,48 @@ var _cirbotok_trans_keys []byte = []byte{
 	219, 220, 221, 222, 223, 224, 225, 226,
 	227, 228, 233, 234, 237, 239, 240, 65,
 	90, 97, 122, 196, 202, 208, 218, 229,
-	236, 128, 191, 170, 181, 186, 128, 191,
-	151, 183, 128, 255, 192, 255, 0, 127,
-	173, 130, 133, 146, 159, 165, 171, 175,
-	191, 192, 255, 181, 190, 128, 175, 176,
-	183, 184, 185, 186, 191, 134, 139, 141,
-	162, 128, 135, 136, 255, 182, 130, 137,
-	176, 151, 152, 154, 160, 136, 191, 192,
-	255, 128, 143, 144, 170, 171, 175, 176,
-	178, 179, 191, 128, 159, 160, 191, 176,
-	128, 138, 139, 173, 174, 255, 148, 150,
-	164, 167, 173, 176, 185, 189, 190, 192,
-	255, 144, 128, 145, 146, 175, 176, 191,
-	128, 140, 141, 255, 166, 176, 178, 191,
-	192, 255, 186, 128, 137, 138, 170, 171,
-	179, 180, 181, 182, 191, 160, 161, 162,
+	236, 128, 191, 170, 177, 181, 186, 128,
+	191, 151, 183, 128, 255, 192, 255, 0,
+	127, 173, 130, 133, 146, 159, 165, 171,
+	175, 191, 192, 255, 181, 190, 128, 175,
+	176, 183, 184, 185, 186, 191, 134, 139,
+	141, 162, 128, 135, 136, 255, 182, 130,
+	137, 176, 151, 152, 154, 160, 136, 191,
+	192, 255, 128, 143, 144, 170, 171, 175,
+	176, 178, 179, 191, 128, 159, 160, 191,
+	176, 128, 138, 139, 173, 174, 255, 148,
+	150, 164, 167, 173, 176, 185, 189, 190,
+	192, 255, 144, 128, 145, 146, 175, 176,
+	191, 128, 140, 141, 255, 166, 176, 178,
+	191, 192, 255, 186, 128, 137, 138, 170,
+	171, 179, 180, 181, 182, 191, 160, 161,
+	162, 164, 165, 166, 167, 168, 169, 170,
+	171, 172, 173, 174, 175, 176, 177, 178,
+	179, 180, 181, 182, 183, 184, 185, 186,
+	187, 188, 189, 190, 128, 191, 128, 129,
+	130, 131, 137, 138, 139, 140, 141, 142,
+	143, 144, 153, 154, 155, 156, 157, 158,
+	159, 160, 161, 162, 163, 164, 165, 166,
+	167, 168, 169, 170, 171, 172, 173, 174,
+	175, 176, 177, 178, 179, 180, 182, 183,
+	184, 188, 189, 190, 191, 132, 187, 129,
+	130, 132, 133, 134, 176, 177, 178, 179,
+	180, 181, 182, 183, 128, 191, 128, 129,
+	130, 131, 132, 133, 134, 135, 144, 136,
+	143, 145, 191, 192, 255, 182, 183, 184,
+	128, 191, 128, 191, 191, 128, 190, 192,
+	255, 128, 146, 147, 148, 152, 153, 154,
+	155, 156, 158, 159, 160, 161, 162, 163,
 	164, 165, 166, 167, 168, 169, 170, 171,
-	172, 173, 174, 175, 176, 177, 178, 179,
-	180, 181, 182, 183, 184, 185, 186, 187,
-	188, 189, 190, 128, 191, 128, 129, 130,
-	131, 137, 138, 139, 140, 141, 142, 143,
-	144, 153, 154, 155, 156, 157, 158, 159,
-	160, 161, 162, 163, 164, 165, 166, 167,
-	168, 169, 170, 171, 172, 173, 174, 175,
-	176, 177, 178, 179, 180, 182, 183, 184,
-	188, 189, 190, 191, 132, 187, 129, 130,
-	132, 133, 134, 176, 177, 178, 179, 180,
-	181, 182, 183, 128, 191, 128, 129, 130,
-	131, 132, 133, 134, 135, 144, 136, 143,
-	145, 191, 192, 255, 182, 183, 184, 128,
-	191, 128, 191, 191, 128, 190, 192, 255,
-	128, 146, 147, 148, 152, 153, 154, 155,
-	156, 158, 159, 160, 161, 162, 163, 164,
-	165, 166, 167, 168, 169, 170, 171, 172,
-	173, 174, 175, 176, 129, 191, 192, 255,
-	158, 159, 128, 157, 160, 191, 192, 255,
-	128, 191, 164, 169, 171, 172, 173, 174,
-	175, 180, 181, 182, 183, 184, 185, 187,
-	188, 189, 190, 191, 128, 163, 165, 186,
-	144, 145, 146, 147, 148, 150, 151, 152,
-	155, 157, 158, 160, 170, 171, 172, 175,
-	128, 159, 161, 169, 173, 191, 128, 191,
+	172, 173, 174, 175, 176, 129, 191, 192,
+	255, 158, 159, 128, 157, 160, 191, 192,
+	255, 128, 191, 164, 169, 171, 172, 173,
+	174, 175, 180, 181, 182, 183, 184, 185,
+	187, 188, 189, 190, 191, 128, 163, 165,
+	186, 144, 145, 146, 147, 148, 150, 151,
+	152, 155, 157, 158, 160, 170, 171, 172,
+	175, 128, 159, 161, 169, 173, 191, 128,
+	191,
 }
 
 var _cirbotok_single_lengths []byte = []byte{
@@ -807,7 +808,7 @@ var _cirbotok_single_lengths []byte = []byte{
 	1, 0, 2, 0, 52, 2, 2, 1,
 	4, 1, 0, 0, 1, 1, 1, 2,
 	1, 3, 2, 1, 2, 36, 1, 2,
-	30, 0, 3, 2, 0, 0, 0, 1,
+	30, 0, 4, 2, 0, 0, 0, 1,
 	2, 4, 1, 0, 1, 0, 0, 0,
 	0, 1, 1, 1, 0, 0, 1, 30,
 	47, 13, 9, 3, 0, 1, 28, 2,
@@ -993,11 +994,11 @@ var _cirbotok_index_offsets []int16 = []int16{
 	3352, 3357, 3360, 3366, 3369, 3436, 3440, 3444,
 	3446, 3456, 3458, 3460, 3462, 3465, 3467, 3469,
 	3472, 3474, 3479, 3482, 3484, 3487, 3529, 3536,
-	3539, 3575, 3577, 3582, 3586, 3587, 3589, 3591,
-	3598, 3605, 3612, 3614, 3616, 3618, 3621, 3624,
-	3630, 3633, 3638, 3645, 3650, 3653, 3657, 3664,
-	3696, 3745, 3760, 3773, 3778, 3780, 3784, 3815,
-	3821, 3823, 3844, 3864,
+	3539, 3575, 3577, 3583, 3587, 3588, 3590, 3592,
+	3599, 3606, 3613, 3615, 3617, 3619, 3622, 3625,
+	3631, 3634, 3639, 3646, 3651, 3654, 3658, 3665,
+	3697, 3746, 3761, 3774, 3779, 3781, 3785, 3816,
+	3822, 3824, 3845, 3865,
 }
 
 var _cirbotok_indicies []int16 = []int16{
@@ -1448,43 +1449,43 @@ var _cirbotok_indicies []int16 = []int16{
 	588, 589, 590, 591, 592, 593, 594, 595,
 	596, 597, 598, 48, 50, 599, 52, 600,
 	601, 22, 22, 25, 25, 49, 565, 414,
-	602, 22, 22, 22, 414, 602, 414, 414,
-	22, 602, 22, 602, 22, 602, 22, 414,
-	414, 414, 414, 414, 602, 22, 414, 414,
-	414, 22, 414, 22, 602, 22, 414, 414,
-	414, 414, 22, 602, 414, 22, 414, 22,
-	414, 22, 414, 414, 22, 414, 602, 22,
-	414, 22, 414, 22, 414, 602, 414, 22,
-	602, 414, 22, 414, 22, 602, 414, 414,
-	414, 414, 414, 602, 22, 22, 414, 22,
-	414, 602, 414, 22, 602, 414, 414, 602,
-	22, 22, 414, 22, 414, 22, 414, 602,
-	603, 604, 605, 606, 607, 608, 609, 610,
-	611, 612, 613, 459, 614, 615, 616, 617,
-	618, 619, 620, 621, 622, 623, 624, 625,
-	624, 626, 627, 628, 629, 630, 415, 602,
-	631, 632, 633, 634, 635, 636, 637, 638,
-	639, 640, 641, 642, 643, 644, 645, 646,
-	647, 648, 649, 469, 650, 651, 652, 436,
-	653, 654, 655, 656, 657, 658, 415, 659,
-	660, 661, 662, 663, 664, 665, 666, 418,
-	667, 415, 418, 668, 669, 670, 671, 427,
-	602, 672, 673, 674, 675, 447, 676, 677,
-	427, 678, 679, 680, 681, 682, 415, 602,
-	683, 642, 684, 685, 686, 427, 687, 688,
-	418, 415, 427, 25, 602, 652, 415, 418,
-	427, 25, 427, 25, 689, 427, 602, 25,
-	418, 690, 691, 418, 692, 693, 425, 694,
-	695, 696, 697, 698, 648, 699, 700, 701,
-	702, 703, 704, 705, 706, 707, 708, 709,
-	710, 667, 711, 418, 427, 25, 602, 712,
-	713, 427, 415, 602, 25, 415, 602, 418,
-	714, 475, 715, 716, 717, 718, 719, 720,
-	721, 722, 415, 723, 724, 725, 726, 727,
-	728, 415, 427, 602, 730, 731, 732, 733,
-	734, 735, 736, 737, 738, 739, 740, 736,
-	742, 743, 744, 745, 729, 741, 729, 602,
-	729, 602,
+	602, 22, 746, 22, 22, 414, 602, 414,
+	414, 22, 602, 22, 602, 22, 602, 22,
+	414, 414, 414, 414, 414, 602, 22, 414,
+	414, 414, 22, 414, 22, 602, 22, 414,
+	414, 414, 414, 22, 602, 414, 22, 414,
+	22, 414, 22, 414, 414, 22, 414, 602,
+	22, 414, 22, 414, 22, 414, 602, 414,
+	22, 602, 414, 22, 414, 22, 602, 414,
+	414, 414, 414, 414, 602, 22, 22, 414,
+	22, 414, 602, 414, 22, 602, 414, 414,
+	602, 22, 22, 414, 22, 414, 22, 414,
+	602, 603, 604, 605, 606, 607, 608, 609,
+	610, 611, 612, 613, 459, 614, 615, 616,
+	617, 618, 619, 620, 621, 622, 623, 624,
+	625, 624, 626, 627, 628, 629, 630, 415,
+	602, 631, 632, 633, 634, 635, 636, 637,
+	638, 639, 640, 641, 642, 643, 644, 645,
+	646, 647, 648, 649, 469, 650, 651, 652,
+	436, 653, 654, 655, 656, 657, 658, 415,
+	659, 660, 661, 662, 663, 664, 665, 666,
+	418, 667, 415, 418, 668, 669, 670, 671,
+	427, 602, 672, 673, 674, 675, 447, 676,
+	677, 427, 678, 679, 680, 681, 682, 415,
+	602, 683, 642, 684, 685, 686, 427, 687,
+	688, 418, 415, 427, 25, 602, 652, 415,
+	418, 427, 25, 427, 25, 689, 427, 602,
+	25, 418, 690, 691, 418, 692, 693, 425,
+	694, 695, 696, 697, 698, 648, 699, 700,
+	701, 702, 703, 704, 705, 706, 707, 708,
+	709, 710, 667, 711, 418, 427, 25, 602,
+	712, 713, 427, 415, 602, 25, 415, 602,
+	418, 714, 475, 715, 716, 717, 718, 719,
+	720, 721, 722, 415, 723, 724, 725, 726,
+	727, 728, 415, 427, 602, 730, 731, 732,
+	733, 734, 735, 736, 737, 738, 739, 740,
+	736, 742, 743, 744, 745, 729, 741, 729,
+	602, 729, 602,
 }
 
 var _cirbotok_trans_targs []int16 = []int16{
@@ -1581,7 +1582,7 @@ var _cirbotok_trans_targs []int16 = []int16{
 	548, 549, 550, 551, 552, 553, 554, 555,
 	556, 557, 558, 587, 612, 615, 616, 618,
 	625, 626, 629, 633, 645, 650, 651, 653,
-	656, 658,
+	656, 658, 660,
 }
 
 var _cirbotok_trans_actions []byte = []byte{
@@ -1678,7 +1679,7 @@ var _cirbotok_trans_actions []byte = []byte{
 	0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0,
+	0, 0, 84,
 }
 
 var _cirbotok_to_state_actions []byte = []byte{
@@ -1975,7 +1976,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 		Pos:      start,
 	}
 
-	// line 95 "scan_tokens.rl"
+	// line 97 "scan_tokens.rl"
 
 	// Ragel state
 	p := 0          // "Pointer" into data
@@ -1986,7 +1987,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 	eof := pe
 	cs := cirbotok_en_main
 
-	// line 113 "scan_tokens.rl"
+	// line 115 "scan_tokens.rl"
 
 	// Make Go compiler happy
 	_ = ts
@@ -2003,17 +2004,24 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 			// should never happen
 			panic("selfToken only works for single-character tokens")
 		}
-		f.emitToken(TokenType(b[0]), ts, te)
+		ty := TokenType(b[0])
+		if ty == TokenPercent && !f.followsNumber(ts) {
+			// A percent sign is a ratio suffix only when it immediately
+			// follows a number literal, as in 50%. Anywhere else it is the
+			// modulo operator.
+			ty = TokenModulo
+		}
+		f.emitToken(ty, ts, te)
 	}
 
-	// line 2019 "scan_tokens.go"
+	// line 2020 "scan_tokens.go"
 	{
 		ts = 0
 		te = 0
 		act = 0
 	}
 
-	// line 2026 "scan_tokens.go"
+	// line 2027 "scan_tokens.go"
 	{
 		var _klen int
 		var _trans int
@@ -2035,7 +2043,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 
 				ts = p
 
-				// line 2047 "scan_tokens.go"
+				// line 2048 "scan_tokens.go"
 			}
 		}
 
@@ -2112,146 +2120,146 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 				te = p + 1
 
 			case 3:
-				// line 72 "scan_tokens.rl"
+				// line 73 "scan_tokens.rl"
 
 				act = 4
 			case 4:
-				// line 74 "scan_tokens.rl"
+				// line 75 "scan_tokens.rl"
 
 				act = 5
 			case 5:
-				// line 89 "scan_tokens.rl"
+				// line 91 "scan_tokens.rl"
 
 				act = 19
 			case 6:
-				// line 91 "scan_tokens.rl"
+				// line 93 "scan_tokens.rl"
 
 				act = 20
 			case 7:
-				// line 92 "scan_tokens.rl"
+				// line 94 "scan_tokens.rl"
 
 				act = 21
 			case 8:
-				// line 71 "scan_tokens.rl"
+				// line 72 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenStringLit)
 				}
 			case 9:
-				// line 72 "scan_tokens.rl"
+				// line 73 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenIdent)
 				}
 			case 10:
-				// line 74 "scan_tokens.rl"
+				// line 75 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenComment)
 				}
 			case 11:
-				// line 77 "scan_tokens.rl"
+				// line 78 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenEqual)
 				}
 			case 12:
-				// line 78 "scan_tokens.rl"
+				// line 79 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenNotEqual)
 				}
 			case 13:
-				// line 79 "scan_tokens.rl"
+				// line 80 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenGreaterThanEq)
 				}
 			case 14:
-				// line 80 "scan_tokens.rl"
+				// line 81 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenLessThanEq)
 				}
 			case 15:
-				// line 81 "scan_tokens.rl"
+				// line 82 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenAnd)
 				}
 			case 16:
-				// line 82 "scan_tokens.rl"
+				// line 83 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenOr)
 				}
 			case 17:
-				// line 83 "scan_tokens.rl"
+				// line 84 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenBarDashDash)
 				}
 			case 18:
-				// line 84 "scan_tokens.rl"
+				// line 85 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenDashDashBar)
 				}
 			case 19:
-				// line 86 "scan_tokens.rl"
+				// line 87 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenDotDot)
 				}
 			case 20:
-				// line 87 "scan_tokens.rl"
+				// line 88 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenOPoint)
 				}
 			case 21:
-				// line 88 "scan_tokens.rl"
+				// line 89 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenCPoint)
 				}
 			case 22:
-				// line 89 "scan_tokens.rl"
+				// line 91 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					selfToken()
 				}
 			case 23:
-				// line 91 "scan_tokens.rl"
+				// line 93 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenBadUTF8)
 				}
 			case 24:
-				// line 92 "scan_tokens.rl"
+				// line 94 "scan_tokens.rl"
 
 				te = p + 1
 				{
 					token(TokenInvalid)
 				}
 			case 25:
-				// line 69 "scan_tokens.rl"
+				// line 70 "scan_tokens.rl"
 
 				te = p
 				p--
@@ -2259,7 +2267,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					token(TokenWhitespace)
 				}
 			case 26:
-				// line 70 "scan_tokens.rl"
+				// line 71 "scan_tokens.rl"
 
 				te = p
 				p--
@@ -2267,7 +2275,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					token(TokenNumberLit)
 				}
 			case 27:
-				// line 72 "scan_tokens.rl"
+				// line 73 "scan_tokens.rl"
 
 				te = p
 				p--
@@ -2275,7 +2283,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					token(TokenIdent)
 				}
 			case 28:
-				// line 74 "scan_tokens.rl"
+				// line 75 "scan_tokens.rl"
 
 				te = p
 				p--
@@ -2283,7 +2291,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					token(TokenComment)
 				}
 			case 29:
-				// line 85 "scan_tokens.rl"
+				// line 86 "scan_tokens.rl"
 
 				te = p
 				p--
@@ -2291,7 +2299,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					token(TokenDashDash)
 				}
 			case 30:
-				// line 89 "scan_tokens.rl"
+				// line 91 "scan_tokens.rl"
 
 				te = p
 				p--
@@ -2299,7 +2307,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					selfToken()
 				}
 			case 31:
-				// line 91 "scan_tokens.rl"
+				// line 93 "scan_tokens.rl"
 
 				te = p
 				p--
@@ -2307,7 +2315,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					token(TokenBadUTF8)
 				}
 			case 32:
-				// line 92 "scan_tokens.rl"
+				// line 94 "scan_tokens.rl"
 
 				te = p
 				p--
@@ -2315,35 +2323,35 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					token(TokenInvalid)
 				}
 			case 33:
-				// line 70 "scan_tokens.rl"
+				// line 71 "scan_tokens.rl"
 
 				p = (te) - 1
 				{
 					token(TokenNumberLit)
 				}
 			case 34:
-				// line 72 "scan_tokens.rl"
+				// line 73 "scan_tokens.rl"
 
 				p = (te) - 1
 				{
 					token(TokenIdent)
 				}
 			case 35:
-				// line 89 "scan_tokens.rl"
+				// line 91 "scan_tokens.rl"
 
 				p = (te) - 1
 				{
 					selfToken()
 				}
 			case 36:
-				// line 91 "scan_tokens.rl"
+				// line 93 "scan_tokens.rl"
 
 				p = (te) - 1
 				{
 					token(TokenBadUTF8)
 				}
 			case 37:
-				// line 92 "scan_tokens.rl"
+				// line 94 "scan_tokens.rl"
 
 				p = (te) - 1
 				{
@@ -2380,7 +2388,14 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 					}
 				}
 
-				// line 2321 "scan_tokens.go"
+			case 39:
+				// line 90 "scan_tokens.rl"
+
+				te = p + 1
+				{
+					token(TokenPlusMinus)
+				}
+				// line 2329 "scan_tokens.go"
 			}
 		}
 
@@ -2396,7 +2411,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 
 				ts = 0
 
-				// line 2336 "scan_tokens.go"
+				// line 2344 "scan_tokens.go"
 			}
 		}
 
@@ -2416,7 +2431,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
 
 	}
 
-	// line 136 "scan_tokens.rl"
+	// line 138 "scan_tokens.rl"
 
 	// If we fall out here without being in a final state then we've
 	// encountered something that the scanner can't match, which we'll
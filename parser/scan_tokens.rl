@@ -54,6 +54,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
         DotDot = "..";
         BarDashDash = "|--";
         DashDashBar = "--|";
+        PlusMinus = "±";
 
         Newline = '\r' ? '\n';
         EndOfLine = Newline;
@@ -86,6 +87,7 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
             DotDot           => { token(TokenDotDot); };
             OPointyPointy    => { token(TokenOPoint); };
             CPointyPointy    => { token(TokenCPoint); };
+            PlusMinus        => { token(TokenPlusMinus); };
             SelfToken        => { selfToken() };
 
             BrokenUTF8       => { token(TokenBadUTF8) };
@@ -127,7 +129,14 @@ func scanTokens(data []byte, filename projpath.FilePath, start source.Pos, mode
             // should never happen
             panic("selfToken only works for single-character tokens")
         }
-        f.emitToken(TokenType(b[0]), ts, te)
+        ty := TokenType(b[0])
+        if ty == TokenPercent && !f.followsNumber(ts) {
+            // A percent sign is a ratio suffix only when it immediately
+            // follows a number literal, as in 50%. Anywhere else it is the
+            // modulo operator.
+            ty = TokenModulo
+        }
+        f.emitToken(ty, ts, te)
     }
 
     %%{
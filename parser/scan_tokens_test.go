@@ -357,6 +357,146 @@ func TestScanTokens(t *testing.T) {
 				},
 			},
 		},
+		{
+			`a % 3`,
+			[]Token{
+				{
+					Type:  TokenIdent,
+					Bytes: []byte(`a`),
+					Range: source.Range{
+						Start: source.Pos{Byte: 0, Line: 1, Column: 1},
+						End:   source.Pos{Byte: 1, Line: 1, Column: 2},
+					},
+				},
+				{
+					Type:  TokenWhitespace,
+					Bytes: []byte(` `),
+					Range: source.Range{
+						Start: source.Pos{Byte: 1, Line: 1, Column: 2},
+						End:   source.Pos{Byte: 2, Line: 1, Column: 3},
+					},
+				},
+				{
+					Type:  TokenModulo,
+					Bytes: []byte(`%`),
+					Range: source.Range{
+						Start: source.Pos{Byte: 2, Line: 1, Column: 3},
+						End:   source.Pos{Byte: 3, Line: 1, Column: 4},
+					},
+				},
+				{
+					Type:  TokenWhitespace,
+					Bytes: []byte(` `),
+					Range: source.Range{
+						Start: source.Pos{Byte: 3, Line: 1, Column: 4},
+						End:   source.Pos{Byte: 4, Line: 1, Column: 5},
+					},
+				},
+				{
+					Type:  TokenNumberLit,
+					Bytes: []byte(`3`),
+					Range: source.Range{
+						Start: source.Pos{Byte: 4, Line: 1, Column: 5},
+						End:   source.Pos{Byte: 5, Line: 1, Column: 6},
+					},
+				},
+				{
+					Type:  TokenEOF,
+					Bytes: []byte{},
+					Range: source.Range{
+						Start: source.Pos{Byte: 5, Line: 1, Column: 6},
+						End:   source.Pos{Byte: 5, Line: 1, Column: 6},
+					},
+				},
+			},
+		},
+		{
+			`10 %`,
+			[]Token{
+				{
+					Type:  TokenNumberLit,
+					Bytes: []byte(`10`),
+					Range: source.Range{
+						Start: source.Pos{Byte: 0, Line: 1, Column: 1},
+						End:   source.Pos{Byte: 2, Line: 1, Column: 3},
+					},
+				},
+				{
+					Type:  TokenWhitespace,
+					Bytes: []byte(` `),
+					Range: source.Range{
+						Start: source.Pos{Byte: 2, Line: 1, Column: 3},
+						End:   source.Pos{Byte: 3, Line: 1, Column: 4},
+					},
+				},
+				{
+					Type:  TokenModulo,
+					Bytes: []byte(`%`),
+					Range: source.Range{
+						Start: source.Pos{Byte: 3, Line: 1, Column: 4},
+						End:   source.Pos{Byte: 4, Line: 1, Column: 5},
+					},
+				},
+				{
+					Type:  TokenEOF,
+					Bytes: []byte{},
+					Range: source.Range{
+						Start: source.Pos{Byte: 4, Line: 1, Column: 5},
+						End:   source.Pos{Byte: 4, Line: 1, Column: 5},
+					},
+				},
+			},
+		},
+		{
+			`±1`,
+			[]Token{
+				{
+					Type:  TokenPlusMinus,
+					Bytes: []byte(`±`),
+					Range: source.Range{
+						Start: source.Pos{Byte: 0, Line: 1, Column: 1},
+						End:   source.Pos{Byte: 2, Line: 1, Column: 2},
+					},
+				},
+				{
+					Type:  TokenNumberLit,
+					Bytes: []byte(`1`),
+					Range: source.Range{
+						Start: source.Pos{Byte: 2, Line: 1, Column: 2},
+						End:   source.Pos{Byte: 3, Line: 1, Column: 3},
+					},
+				},
+				{
+					Type:  TokenEOF,
+					Bytes: []byte{},
+					Range: source.Range{
+						Start: source.Pos{Byte: 3, Line: 1, Column: 3},
+						End:   source.Pos{Byte: 3, Line: 1, Column: 3},
+					},
+				},
+			},
+		},
+		{
+			`°`,
+			[]Token{
+				{
+					Type:  TokenInvalid,
+					Bytes: []byte(`°`),
+					Range: source.Range{
+						Start: source.Pos{Byte: 0, Line: 1, Column: 1},
+						End:   source.Pos{Byte: 2, Line: 1, Column: 2},
+					},
+				},
+				{
+					Type:  TokenEOF,
+					Bytes: []byte{},
+					Range: source.Range{
+						Start: source.Pos{Byte: 2, Line: 1, Column: 2},
+						End:   source.Pos{Byte: 2, Line: 1, Column: 2},
+					},
+				},
+			},
+		},
 		{
 			`10mm`,
 			[]Token{
@@ -42,8 +42,11 @@ const (
 	TokenPlus    TokenType = '+'
 	TokenMinus   TokenType = '-'
 	TokenPercent TokenType = '%'
+	TokenModulo  TokenType = '％'
 	TokenCaret   TokenType = '^'
 
+	TokenPlusMinus TokenType = '±'
+
 	TokenAssign        TokenType = '='
 	TokenEqual         TokenType = '≔'
 	TokenNotEqual      TokenType = '≠'
@@ -146,6 +149,16 @@ func (f *tokenAccum) emitToken(ty TokenType, startOfs, endOfs int) {
 	})
 }
 
+// followsNumber returns true if the most recently emitted token is a number
+// literal that ends at the given offset, with no whitespace between.
+func (f *tokenAccum) followsNumber(ofs int) bool {
+	if len(f.Tokens) == 0 {
+		return false
+	}
+	last := f.Tokens[len(f.Tokens)-1]
+	return last.Type == TokenNumberLit && last.Range.End.Byte == ofs
+}
+
 type heredocInProgress struct {
 	Marker      []byte
 	StartOfLine bool
@@ -4,7 +4,7 @@ package parser
 
 import "fmt"
 
-const _TokenType_name = "TokenNilTokenWhitespaceTokenBangTokenPercentTokenBitwiseAndTokenOParenTokenCParenTokenStarTokenPlusTokenCommaTokenMinusTokenDotTokenSlashTokenColonTokenSemicolonTokenLessThanTokenAssignTokenGreaterThanTokenQuestionTokenCommentTokenIdentTokenNumberLitTokenStringLitTokenOBrackTokenCBrackTokenCaretTokenOBraceTokenBitwiseOrTokenCBraceTokenBitwiseNotTokenOPointTokenCPointTokenDashDashTokenDotDotTokenAndTokenOrTokenEqualTokenNotEqualTokenLessThanEqTokenGreaterThanEqTokenEOFTokenBarDashDashTokenDashDashBarTokenInvalidTokenBadUTF8"
+const _TokenType_name = "TokenNilTokenWhitespaceTokenBangTokenPercentTokenBitwiseAndTokenOParenTokenCParenTokenStarTokenPlusTokenCommaTokenMinusTokenDotTokenSlashTokenColonTokenSemicolonTokenLessThanTokenAssignTokenGreaterThanTokenQuestionTokenCommentTokenIdentTokenNumberLitTokenStringLitTokenOBrackTokenCBrackTokenCaretTokenOBraceTokenBitwiseOrTokenCBraceTokenBitwiseNotTokenOPointTokenPlusMinusTokenCPointTokenDashDashTokenDotDotTokenAndTokenOrTokenEqualTokenNotEqualTokenLessThanEqTokenGreaterThanEqTokenEOFTokenBarDashDashTokenDashDashBarTokenModuloTokenInvalidTokenBadUTF8"
 
 var _TokenType_map = map[TokenType]string{
 	0:      _TokenType_name[0:8],
@@ -38,20 +38,22 @@ var _TokenType_map = map[TokenType]string{
 	125:    _TokenType_name[321:332],
 	126:    _TokenType_name[332:347],
 	171:    _TokenType_name[347:358],
-	187:    _TokenType_name[358:369],
-	8212:   _TokenType_name[369:382],
-	8230:   _TokenType_name[382:393],
-	8743:   _TokenType_name[393:401],
-	8744:   _TokenType_name[401:408],
-	8788:   _TokenType_name[408:418],
-	8800:   _TokenType_name[418:431],
-	8804:   _TokenType_name[431:446],
-	8805:   _TokenType_name[446:464],
-	9220:   _TokenType_name[464:472],
-	9500:   _TokenType_name[472:488],
-	9508:   _TokenType_name[488:504],
-	65533:  _TokenType_name[504:516],
-	128169: _TokenType_name[516:528],
+	177:    _TokenType_name[358:372],
+	187:    _TokenType_name[372:383],
+	8212:   _TokenType_name[383:396],
+	8230:   _TokenType_name[396:407],
+	8743:   _TokenType_name[407:415],
+	8744:   _TokenType_name[415:422],
+	8788:   _TokenType_name[422:432],
+	8800:   _TokenType_name[432:445],
+	8804:   _TokenType_name[445:460],
+	8805:   _TokenType_name[460:478],
+	9220:   _TokenType_name[478:486],
+	9500:   _TokenType_name[486:502],
+	9508:   _TokenType_name[502:518],
+	65285:  _TokenType_name[518:529],
+	65533:  _TokenType_name[529:541],
+	128169: _TokenType_name[541:553],
 }
 
 func (i TokenType) String() string {
@@ -0,0 +1,19 @@
+// Package spice produces SPICE netlists (".cir" decks) from evaluated Cirbo
+// designs, so that they can be simulated with tools such as ngspice.
+//
+// Each device instance in the design is mapped to a SPICE element using one
+// of the following strategies, in order of preference:
+//
+//     - If the device has a string attribute named "spice_model" then the
+//       device is emitted as a subcircuit instance ("X" element) referring
+//       to a subcircuit of that name, or, if the device's designator is one
+//       of the SPICE semiconductor element letters D, Q, J or M, as an
+//       element of that type using the named model.
+//
+//     - If the device's designator is R, C or L and it has an attribute of
+//       type Resistance, Capacitance or Inductance respectively, the device
+//       is emitted as the corresponding SPICE primitive with that value.
+//
+// Devices that cannot be mapped are noted in a comment in the output but
+// are otherwise excluded from the deck.
+package spice
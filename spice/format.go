@@ -0,0 +1,72 @@
+package spice
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// spiceScale describes one of the scale factor suffixes that SPICE accepts
+// after a number.
+type spiceScale struct {
+	Exp    int
+	Suffix string
+}
+
+// spiceScales are the scale factors used by FormatQuantity, from largest
+// to smallest. SPICE suffixes are case-insensitive, which is why "Meg" is
+// needed to distinguish mega from milli.
+var spiceScales = []spiceScale{
+	{12, "T"},
+	{9, "G"},
+	{6, "Meg"},
+	{3, "k"},
+	{0, ""},
+	{-3, "m"},
+	{-6, "u"},
+	{-9, "n"},
+	{-12, "p"},
+	{-15, "f"},
+}
+
+// FormatQuantity returns the given quantity formatted as a SPICE number.
+//
+// The quantity is first converted to standard (unscaled SI) units and then
+// written with an engineering scale suffix where appropriate, so that
+// for example 4.7 kohm becomes "4.7k" and 100 nF becomes "100n". SPICE
+// does not use the unit itself, so it is not included in the result.
+func FormatQuantity(q units.Quantity) string {
+	v, _ := q.WithStandardUnits().Value().Float64()
+	return formatNumber(v)
+}
+
+func formatNumber(v float64) string {
+	if v == 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	mag := math.Abs(v)
+	for _, scale := range spiceScales {
+		factor := math.Pow10(scale.Exp)
+		// A small tolerance lets values like 999.9999999999 that are
+		// really 1000 after rounding select the larger scale.
+		if mag >= factor*(1-1e-12) {
+			return trimFloat(v/factor) + scale.Suffix
+		}
+	}
+
+	// Smaller than the smallest suffix, so we'll just use exponent notation.
+	return strconv.FormatFloat(v, 'g', 12, 64)
+}
+
+// trimFloat formats a float with enough precision to be useful while
+// avoiding spurious digits from binary floating point rounding.
+func trimFloat(v float64) string {
+	s := strconv.FormatFloat(v, 'g', 12, 64)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,151 @@
+package spice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+)
+
+// groundName is the node name that SPICE reserves for the reference node.
+const groundName = "0"
+
+// nodeNamer assigns SPICE node names to the nets in a design.
+//
+// Since SPICE netlists are flat, nets that are joined through the terminals
+// of nested circuit instances must be given the same node name, so names
+// are assigned to the groups produced by cbo.CircuitInstance.FlatNets.
+type nodeNamer struct {
+	flat    map[*cbo.Net]*cbo.Net
+	members map[*cbo.Net][]*cbo.Net
+	names   map[*cbo.Net]string
+	used    map[string]bool
+	next    int
+}
+
+func newNodeNamer(root *cbo.CircuitInstance) *nodeNamer {
+	n := &nodeNamer{
+		flat:    root.FlatNets(),
+		members: map[*cbo.Net][]*cbo.Net{},
+		names:   map[*cbo.Net]string{},
+		used:    map[string]bool{},
+	}
+	for net, rep := range n.flat {
+		n.members[rep] = append(n.members[rep], net)
+	}
+	return n
+}
+
+func (n *nodeNamer) find(net *cbo.Net) *cbo.Net {
+	if rep, exists := n.flat[net]; exists {
+		return rep
+	}
+	return net
+}
+
+// NodeName returns the node name for the given endpoint.
+//
+// Names are assigned on first request, so callers should request names in
+// a deterministic order in order to get deterministic results. An endpoint
+// that is not connected to anything gets a unique node name of its own.
+func (n *nodeNamer) NodeName(ep *cbo.Endpoint) string {
+	if ep.Net == nil || len(ep.Net.Endpoints) < 2 && len(n.members[n.find(ep.Net)]) < 2 {
+		return n.fresh("NC")
+	}
+	return n.NetName(ep.Net)
+}
+
+// NetName returns the node name for the given net, which is shared by all
+// of the nets in the same group.
+func (n *nodeNamer) NetName(net *cbo.Net) string {
+	rep := n.find(net)
+	if name, exists := n.names[rep]; exists {
+		return name
+	}
+
+	nets := n.Members(net)
+
+	// The members of a group are unordered, so we sort the candidate names
+	// to get a deterministic result. Since "0" sorts before any letter,
+	// ground wins over any other name in the same group.
+	var candidates []string
+	for _, net := range nets {
+		suggested := net.SuggestedName()
+		switch suggested {
+		case "":
+			continue
+		case "GND":
+			candidates = append(candidates, groundName)
+		default:
+			candidates = append(candidates, nodeNameReplacer.Replace(suggested))
+		}
+	}
+	sort.Strings(candidates)
+
+	var name string
+	for _, candidate := range candidates {
+		if !n.used[candidate] {
+			name = candidate
+			break
+		}
+	}
+	if name == "" {
+		name = n.fresh("N")
+	}
+
+	n.used[name] = true
+	n.names[rep] = name
+	return name
+}
+
+// AssignedName returns the node name already assigned to the given net by
+// an earlier call to NodeName or NetName, if any. Unlike NetName, this never
+// assigns a new name, so it is safe to call in any order.
+func (n *nodeNamer) AssignedName(net *cbo.Net) (string, bool) {
+	name, exists := n.names[n.find(net)]
+	return name, exists
+}
+
+// Members returns all of the nets in the same group as the given net,
+// including the net itself, in no particular order.
+func (n *nodeNamer) Members(net *cbo.Net) []*cbo.Net {
+	if nets := n.members[n.find(net)]; len(nets) > 0 {
+		return nets
+	}
+	return []*cbo.Net{net}
+}
+
+// Nets returns all of the nets known to the receiver, in no particular
+// order.
+func (n *nodeNamer) Nets() []*cbo.Net {
+	ret := make([]*cbo.Net, 0, len(n.flat))
+	for net := range n.flat {
+		ret = append(ret, net)
+	}
+	return ret
+}
+
+func (n *nodeNamer) fresh(prefix string) string {
+	for {
+		n.next++
+		name := fmt.Sprintf("%s%03d", prefix, n.next)
+		if !n.used[name] {
+			n.used[name] = true
+			return name
+		}
+	}
+}
+
+// nodeNameReplacer rewrites characters that commonly appear in net names
+// but that are not safe to use in SPICE node names.
+var nodeNameReplacer = strings.NewReplacer(
+	"+", "P",
+	"-", "N",
+	" ", "_",
+	".", "_",
+	"=", "_",
+	"(", "_",
+	")", "_",
+	",", "_",
+)
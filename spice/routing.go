@@ -0,0 +1,96 @@
+package spice
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// writeRouting writes comment lines describing the net classes and
+// differential pairs in the design, if any.
+//
+// SPICE itself has no use for routing constraints, but netlists are also
+// consumed by layout tools, so we include them in a form that is easy to
+// extract while remaining inert for simulators:
+//
+//     * netclass <name> [width=<len>] [clearance=<len>] [via=<len>]: <nodes>
+//     * diffpair <name> <positive> <negative> [impedance=<value>]
+//
+// Lengths are given in millimeters.
+//
+// This must be called after all of the elements have been written, since
+// only nets that already have node names are described. Assigning names
+// here would make the names depend on the order of iteration over nets.
+func writeRouting(buf *bytes.Buffer, nodes *nodeNamer) {
+	classNodes := map[*cbo.NetClass]map[string]bool{}
+	pairs := map[*cbo.DiffPair]bool{}
+	for _, net := range nodes.Nets() {
+		name, named := nodes.AssignedName(net)
+		if !named {
+			continue
+		}
+		if net.Class != nil {
+			if classNodes[net.Class] == nil {
+				classNodes[net.Class] = map[string]bool{}
+			}
+			classNodes[net.Class][name] = true
+		}
+		if net.DiffPair != nil {
+			pairs[net.DiffPair] = true
+		}
+	}
+
+	classes := make([]*cbo.NetClass, 0, len(classNodes))
+	for class := range classNodes {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+	for _, class := range classes {
+		fields := []string{"*", "netclass", class.Name}
+		fields = appendLength(fields, "width", class.TraceWidth)
+		fields = appendLength(fields, "clearance", class.Clearance)
+		fields = appendLength(fields, "via", class.ViaSize)
+
+		names := make([]string, 0, len(classNodes[class]))
+		for name := range classNodes[class] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Fprintf(buf, "%s: %s\n", strings.Join(fields, " "), strings.Join(names, " "))
+	}
+
+	sortedPairs := make([]*cbo.DiffPair, 0, len(pairs))
+	for pair := range pairs {
+		sortedPairs = append(sortedPairs, pair)
+	}
+	sort.Slice(sortedPairs, func(i, j int) bool {
+		return sortedPairs[i].Name < sortedPairs[j].Name
+	})
+	for _, pair := range sortedPairs {
+		pos, posNamed := nodes.AssignedName(pair.Positive)
+		neg, negNamed := nodes.AssignedName(pair.Negative)
+		if !posNamed || !negNamed {
+			continue
+		}
+		fields := []string{"*", "diffpair", pair.Name, pos, neg}
+		if pair.Impedance != nil {
+			fields = append(fields, "impedance="+FormatQuantity(*pair.Impedance))
+		}
+		fmt.Fprintln(buf, strings.Join(fields, " "))
+	}
+}
+
+func appendLength(fields []string, name string, q *units.Quantity) []string {
+	if q == nil {
+		return fields
+	}
+	v, _ := q.Convert(units.Millimeter).Value().Float64()
+	return append(fields, fmt.Sprintf("%s=%smm", name, trimFloat(v)))
+}
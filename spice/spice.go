@@ -0,0 +1,166 @@
+package spice
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+// ModelAttr is the name of the reserved device attribute that selects the
+// SPICE model or subcircuit used to simulate a device.
+const ModelAttr = "spice_model"
+
+// Options customizes the output produced by Write.
+type Options struct {
+	// Title is written as the first line of the deck, which SPICE always
+	// treats as a title. If empty, the name of the root circuit is used.
+	Title string
+}
+
+// Write writes a SPICE netlist describing the given root circuit instance
+// and everything nested within it to the given writer.
+//
+// Since SPICE netlists are flat, nested circuit instances are expanded
+// in-place, with each element named after its full instance path. The net
+// with the suggested name "GND" is used as the SPICE ground node, "0".
+// ERC-only devices are not included. Any net classes and differential
+// pairs are described in comments at the end of the deck, for the benefit
+// of layout tools that consume the netlist.
+//
+// The result is deterministic for a given design. An error is returned only
+// if writing to the given writer fails.
+func Write(w io.Writer, root *cbo.CircuitInstance, opts Options) error {
+	var buf bytes.Buffer
+
+	title := opts.Title
+	if title == "" {
+		title = root.Name
+		if root.Circuit != nil && root.Circuit.Name != "" {
+			title = root.Circuit.Name
+		}
+	}
+	// The title line is not parsed, but it must be a single line.
+	fmt.Fprintf(&buf, "* %s\n", strings.Replace(title, "\n", " ", -1))
+
+	nodes := newNodeNamer(root)
+	root.WalkDevices(func(path cbo.InstancePath, inst *cbo.DeviceInstance) {
+		if inst.Device != nil && inst.Device.ERCOnly {
+			// ERC-only devices have no physical counterpart.
+			return
+		}
+		line, err := deviceElement(path, inst, nodes)
+		if err != nil {
+			fmt.Fprintf(&buf, "* %s: %s\n", path, err)
+			return
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	})
+
+	writeRouting(&buf, nodes)
+
+	buf.WriteString(".end\n")
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// deviceElement returns the SPICE element line for the given device
+// instance, or an error describing why the device cannot be mapped.
+func deviceElement(path cbo.InstancePath, inst *cbo.DeviceInstance, nodes *nodeNamer) (string, error) {
+	designator := strings.ToUpper(inst.Designator)
+
+	if model, ok := inst.Attrs[ModelAttr].(string); ok && model != "" {
+		switch designator {
+		case "D", "Q", "J", "M":
+			return element(designator, path, deviceNodes(inst, nodes), model), nil
+		default:
+			return element("X", path, deviceNodes(inst, nodes), model), nil
+		}
+	}
+
+	var unit *units.Unit
+	switch designator {
+	case "R":
+		unit = units.Ohm
+	case "C":
+		unit = units.Farad
+	case "L":
+		unit = units.Henry
+	default:
+		return "", fmt.Errorf("device %s has no %q attribute", deviceName(inst), ModelAttr)
+	}
+
+	val, ok := primitiveValue(inst, unit)
+	if !ok {
+		return "", fmt.Errorf("device %s has no attribute of a type commensurable with %s", deviceName(inst), unit)
+	}
+
+	nodeNames := deviceNodes(inst, nodes)
+	if len(nodeNames) != 2 {
+		return "", fmt.Errorf("device %s has %d terminal endpoints, but SPICE %s elements require exactly two", deviceName(inst), len(nodeNames), designator)
+	}
+
+	return element(designator, path, nodeNames, FormatQuantity(val)), nil
+}
+
+// primitiveValue returns the value of the device's first attribute, in
+// lexical order by name, that is a quantity commensurable with the given
+// unit.
+func primitiveValue(inst *cbo.DeviceInstance, unit *units.Unit) (units.Quantity, bool) {
+	names := make([]string, 0, len(inst.Attrs))
+	for name := range inst.Attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		q, ok := inst.Attrs[name].(units.Quantity)
+		if ok && q.ConvertableTo(unit) {
+			return q, true
+		}
+	}
+	return units.Quantity{}, false
+}
+
+// deviceNodes returns the node names for each of the endpoints of the given
+// device's terminals, in the order the terminals were declared.
+func deviceNodes(inst *cbo.DeviceInstance, nodes *nodeNamer) []string {
+	var ret []string
+	for _, termName := range inst.TerminalNames() {
+		for _, ep := range inst.Terminals[termName].Outside {
+			ret = append(ret, nodes.NodeName(ep))
+		}
+	}
+	return ret
+}
+
+// element formats a single SPICE element line.
+//
+// The element name is derived from the instance path, with the given type
+// letter prepended unless the name already starts with it, so that a
+// resistor named R1 remains R1.
+func element(letter string, path cbo.InstancePath, nodeNames []string, value string) string {
+	name := strings.Join(path, "_")
+	if !strings.HasPrefix(strings.ToUpper(name), letter) {
+		name = letter + name
+	}
+
+	fields := make([]string, 0, len(nodeNames)+2)
+	fields = append(fields, name)
+	fields = append(fields, nodeNames...)
+	fields = append(fields, value)
+	return strings.Join(fields, " ")
+}
+
+func deviceName(inst *cbo.DeviceInstance) string {
+	if inst.Device == nil {
+		return "<unknown>"
+	}
+	return inst.Device.Name
+}
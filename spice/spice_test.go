@@ -0,0 +1,190 @@
+package spice
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cirbo-lang/cirbo/cbo"
+	"github.com/cirbo-lang/cirbo/units"
+)
+
+func TestWrite(t *testing.T) {
+	root := testDesign()
+
+	var buf bytes.Buffer
+	err := Write(&buf, root, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := buf.String()
+	want := `* Top
+C1 P5V 0 100n
+R1 P5V N001 4.7k
+XU1 NC002 0 P5V LM7805
+* X1: device Mystery has no "spice_model" attribute
+Dled_D1 N001 0 LED1
+.end
+`
+	if got != want {
+		t.Errorf("wrong result\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteRouting(t *testing.T) {
+	root := testDesign()
+	r1 := root.Devices["R1"]
+	d1 := root.Circuits["led"].Devices["D1"]
+	out := r1.Terminals["A"].Outside[0].Net
+	inner := d1.Terminals["A"].Outside[0].Net
+
+	width := units.MakeQuantityFloat(0.25, units.Millimeter)
+	clearance := units.MakeQuantityInt(8, units.Mil)
+	class := &cbo.NetClass{
+		Name:       "Power",
+		TraceWidth: &width,
+		Clearance:  &clearance,
+	}
+	for _, net := range []*cbo.Net{out, inner} {
+		if err := net.SetClass(class); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	impedance := units.MakeQuantityInt(90, units.Ohm)
+	if _, err := cbo.NewDiffPair("PAIR", out, inner, &impedance); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var buf bytes.Buffer
+	err := Write(&buf, root, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := buf.String()
+	want := `* Top
+C1 P5V 0 100n
+R1 P5V N001 4.7k
+XU1 NC002 0 P5V LM7805
+* X1: device Mystery has no "spice_model" attribute
+Dled_D1 N001 0 LED1
+* netclass Power width=0.25mm clearance=0.2032mm: N001 P5V
+* diffpair PAIR P5V N001 impedance=90
+.end
+`
+	if got != want {
+		t.Errorf("wrong result\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatQuantity(t *testing.T) {
+	tests := []struct {
+		Input units.Quantity
+		Want  string
+	}{
+		{units.MakeQuantityInt(0, units.Ohm), "0"},
+		{units.MakeQuantityInt(1, units.Ohm), "1"},
+		{units.MakeQuantityInt(47, units.ByName("kohm")), "47k"},
+		{units.MakeQuantityInt(1000, units.Ohm), "1k"},
+		{units.MakeQuantityFloat(2.2, units.ByName("Mohm")), "2.2Meg"},
+		{units.MakeQuantityFloat(0.1, units.ByName("uF")), "100n"},
+		{units.MakeQuantityInt(10, units.ByName("uH")), "10u"},
+		{units.MakeQuantityInt(-5, units.ByName("mV")), "-5m"},
+		{units.MakeQuantityInt(3, units.ByName("MHz")), "3Meg"},
+		{units.MakeQuantityFloat(1.5e-18, units.Farad), "1.5e-18"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input.String(), func(t *testing.T) {
+			got := FormatQuantity(test.Input)
+			if got != test.Want {
+				t.Errorf("wrong result %q; want %q", got, test.Want)
+			}
+		})
+	}
+}
+
+// testDesign returns a small design to use in tests: a regulator feeding
+// an RC network at the top level, a diode inside a nested circuit, and a
+// device that has no SPICE mapping.
+func testDesign() *cbo.CircuitInstance {
+	u1 := testDevice("U1", "U", "Regulator", "VIN", "GND", "+5V")
+	u1.Attrs[ModelAttr] = "LM7805"
+	r1 := testDevice("R1", "R", "Resistor", "A", "B")
+	r1.Attrs["resistance"] = units.MakeQuantityFloat(4.7, units.ByName("kohm"))
+	c1 := testDevice("C1", "C", "Capacitor", "A", "B")
+	c1.Attrs["capacitance"] = units.MakeQuantityFloat(0.1, units.ByName("uF"))
+	x1 := testDevice("X1", "X", "Mystery", "A")
+	d1 := testDevice("D1", "D", "LED", "A", "K")
+	d1.Attrs[ModelAttr] = "LED1"
+
+	indicator := &cbo.Circuit{
+		Name: "Indicator",
+		Terminals: cbo.TerminalsDef{
+			All: map[string]cbo.Terminal{
+				"A": {Name: "A"},
+			},
+			Names: []string{"A"},
+		},
+	}
+	led := indicator.NewInstance("led", nil)
+	led.Devices = map[string]*cbo.DeviceInstance{"D1": d1}
+
+	root := &cbo.CircuitInstance{
+		Circuit: &cbo.Circuit{Name: "Top"},
+		Name:    "top",
+		Devices: map[string]*cbo.DeviceInstance{
+			"U1": u1,
+			"R1": r1,
+			"C1": c1,
+			"X1": x1,
+		},
+		Circuits: map[string]*cbo.CircuitInstance{
+			"led": led,
+		},
+	}
+
+	gnd := &cbo.Net{Endpoints: cbo.EndpointSet{}}
+	gnd.Connect(u1.Terminals["GND"].Outside[0])
+	gnd.Connect(c1.Terminals["B"].Outside[0])
+	gnd.Connect(d1.Terminals["K"].Outside[0])
+
+	out := &cbo.Net{Endpoints: cbo.EndpointSet{}}
+	out.Connect(u1.Terminals["+5V"].Outside[0])
+	out.Connect(r1.Terminals["A"].Outside[0])
+	out.Connect(c1.Terminals["A"].Outside[0])
+
+	outer := &cbo.Net{Endpoints: cbo.EndpointSet{}}
+	outer.Connect(r1.Terminals["B"].Outside[0])
+	outer.Connect(led.Terminals["A"].Outside[0])
+
+	inner := &cbo.Net{Endpoints: cbo.EndpointSet{}}
+	inner.Connect(led.Terminals["A"].Inside[0])
+	inner.Connect(d1.Terminals["A"].Outside[0])
+
+	return root
+}
+
+func testDevice(name, designator, typeName string, termNames ...string) *cbo.DeviceInstance {
+	dev := &cbo.Device{
+		Name: typeName,
+		Terminals: cbo.TerminalsDef{
+			All:   map[string]cbo.Terminal{},
+			Names: termNames,
+		},
+	}
+	terms := map[string]*cbo.TerminalInstance{}
+	for _, tn := range termNames {
+		term := cbo.Terminal{Name: tn}
+		dev.Terminals.All[tn] = term
+		terms[tn] = term.NewInstance()
+	}
+	return &cbo.DeviceInstance{
+		Device:     dev,
+		Name:       name,
+		Designator: designator,
+		Attrs:      map[string]cbo.Any{},
+		Terminals:  terms,
+	}
+}
@@ -14,58 +14,104 @@ type baseUnits struct {
 	Time              *timeUnit
 	ElectricCurrent   *electricCurrentUnit
 	LuminousIntensity *luminousIntensityUnit
+	Temperature       *temperatureUnit
+
+	// Level is set only for logarithmic units. See levelRef.
+	Level *levelRef
 }
 
 type massUnit struct {
 	Scale big.Float
+	num   number // Scale as a number; see initNumbers
+	name  string // set only for units created by DefineUnit
 }
 
 type lengthUnit struct {
 	Scale big.Float
+	num   number // Scale as a number; see initNumbers
+	name  string // set only for units created by DefineUnit
 }
 
 type angleUnit struct {
 	Scale big.Float
+	num   number // Scale as a number; see initNumbers
+	name  string // set only for units created by DefineUnit
 }
 
 type timeUnit struct {
 	Scale big.Float
+	num   number // Scale as a number; see initNumbers
+	name  string // set only for units created by DefineUnit
 }
 
 type electricCurrentUnit struct {
 	Scale big.Float
+	num   number // Scale as a number; see initNumbers
+	name  string // set only for units created by DefineUnit
 }
 
 type luminousIntensityUnit struct {
 	Scale big.Float
+	num   number // Scale as a number; see initNumbers
+	name  string // set only for units created by DefineUnit
+}
+
+// temperatureUnit differs from the other base units in that the common
+// temperature scales do not share a zero point. Offset is the value, in
+// this unit, of zero kelvin.
+//
+// The offset applies only to absolute temperatures, which have a
+// dimensionality of exactly [Θ]. In any other dimensionality, such as
+// kelvins per watt, a temperature is understood as a temperature
+// difference and so only the scale applies.
+type temperatureUnit struct {
+	Scale  big.Float
+	Offset big.Float
+
+	scaleNum, offsetNum number // see initNumbers
+	name                string // set only for units created by DefineUnit
 }
 
-var kilogram = &massUnit{bf("1")}
-var gram = &massUnit{bf("1000")}
-var pound = &massUnit{bf("2.2046226218487758072297380134502703385420702733602")}
-var stone = &massUnit{bf("30.864716705882861301216332188303784739588983827043")}
+// OffsetTemperature returns true if the receiver is a unit of absolute
+// temperature whose zero point is not absolute zero, such as degrees
+// Celsius.
+//
+// Quantities in such units cannot be multiplied or divided, and have special
+// rules for addition and subtraction; see Quantity.Add and Quantity.Subtract.
+func (u *Unit) OffsetTemperature() bool {
+	return u.dim == Dimensionality{Temperature: 1} && u.base.Temperature != nil && u.base.Temperature.Offset.Sign() != 0
+}
+
+var kilogram = &massUnit{Scale: bf("1")}
+var gram = &massUnit{Scale: bf("1000")}
+var pound = &massUnit{Scale: bf("2.2046226218487758072297380134502703385420702733602")}
+var stone = &massUnit{Scale: bf("30.864716705882861301216332188303784739588983827043")}
 
-var meter = &lengthUnit{bf("1")}
-var centimeter = &lengthUnit{bf("100")}
-var millimeter = &lengthUnit{bf("1000")}
-var kilometer = &lengthUnit{bf("0.001")}
-var yard = &lengthUnit{bf("1.0936132983377077865266841644794400699912510936133")}
-var inch = &lengthUnit{bf("39.370078740157480314960629921259842519685039370079")}
-var foot = &lengthUnit{bf("3.2808398950131233595800524934383202099737532808399")}
-var mil = &lengthUnit{bf("39370.078740157480314960629921259842519685039370079")}
+var meter = &lengthUnit{Scale: bf("1")}
+var centimeter = &lengthUnit{Scale: bf("100")}
+var millimeter = &lengthUnit{Scale: bf("1000")}
+var kilometer = &lengthUnit{Scale: bf("0.001")}
+var yard = &lengthUnit{Scale: bf("1.0936132983377077865266841644794400699912510936133")}
+var inch = &lengthUnit{Scale: bf("39.370078740157480314960629921259842519685039370079")}
+var foot = &lengthUnit{Scale: bf("3.2808398950131233595800524934383202099737532808399")}
+var mil = &lengthUnit{Scale: bf("39370.078740157480314960629921259842519685039370079")}
 
-var degree = &angleUnit{bf("1")}
-var radian = &angleUnit{bf("0.017453292519943295769236907684886127134428718885417")}
-var turn = &angleUnit{bf("0.002777777777777777777777777777777777777777777778")}
+var degree = &angleUnit{Scale: bf("1")}
+var radian = &angleUnit{Scale: bf("0.017453292519943295769236907684886127134428718885417")}
+var turn = &angleUnit{Scale: bf("0.002777777777777777777777777777777777777777777778")}
 
-var second = &timeUnit{bf("1")}
-var millisecond = &timeUnit{bf("1000")}
-var microsecond = &timeUnit{bf("1000000")}
+var second = &timeUnit{Scale: bf("1")}
+var millisecond = &timeUnit{Scale: bf("1000")}
+var microsecond = &timeUnit{Scale: bf("1000000")}
 
-var ampere = &electricCurrentUnit{bf("1")}
-var milliampere = &electricCurrentUnit{bf("1000")}
+var ampere = &electricCurrentUnit{Scale: bf("1")}
+var milliampere = &electricCurrentUnit{Scale: bf("1000")}
 
-var candela = &luminousIntensityUnit{bf("1")}
+var candela = &luminousIntensityUnit{Scale: bf("1")}
+
+var kelvin = &temperatureUnit{Scale: bf("1"), Offset: bf("0")}
+var celsius = &temperatureUnit{Scale: bf("1"), Offset: bf("-273.15")}
+var fahrenheit = &temperatureUnit{Scale: bf("1.8"), Offset: bf("-459.67")}
 
 var massUnits = map[*massUnit]*Unit{
 	kilogram: unitByName["kg"],
@@ -100,6 +146,68 @@ var electricCurrentUnits = map[*electricCurrentUnit]*Unit{
 var luminousIntensityUnits = map[*luminousIntensityUnit]*Unit{
 	candela: unitByName["cd"],
 }
+var temperatureUnits = map[*temperatureUnit]*Unit{
+	kelvin:     unitByName["K"],
+	celsius:    unitByName["degC"],
+	fahrenheit: unitByName["degF"],
+}
+
+func init() {
+	for u := range massUnits {
+		u.initNumbers()
+	}
+	for u := range lengthUnits {
+		u.initNumbers()
+	}
+	for u := range angleUnits {
+		u.initNumbers()
+	}
+	for u := range timeUnits {
+		u.initNumbers()
+	}
+	for u := range electricCurrentUnits {
+		u.initNumbers()
+	}
+	for u := range luminousIntensityUnits {
+		u.initNumbers()
+	}
+	for u := range temperatureUnits {
+		u.initNumbers()
+	}
+}
+
+// initNumbers prepares the numbers that unit conversions use in place of
+// the big.Float fields of a base unit, so that the conversions need not
+// convert these fields on each call. It must be called for each new base
+// unit once its fields are set.
+func (u *massUnit) initNumbers() {
+	u.num = makeNumber(&u.Scale)
+}
+
+func (u *lengthUnit) initNumbers() {
+	u.num = makeNumber(&u.Scale)
+}
+
+func (u *angleUnit) initNumbers() {
+	u.num = makeNumber(&u.Scale)
+}
+
+func (u *timeUnit) initNumbers() {
+	u.num = makeNumber(&u.Scale)
+}
+
+func (u *electricCurrentUnit) initNumbers() {
+	u.num = makeNumber(&u.Scale)
+}
+
+func (u *luminousIntensityUnit) initNumbers() {
+	u.num = makeNumber(&u.Scale)
+}
+
+func (u *temperatureUnit) initNumbers() {
+	u.scaleNum = makeNumber(&u.Scale)
+	u.offsetNum = makeNumber(&u.Offset)
+}
 
 func bfp(s string) *big.Float {
 	ret := &big.Float{}
@@ -124,12 +232,13 @@ func bff(v float64) big.Float {
 	return *f
 }
 
-func powerScale(scale *big.Float, power int) *big.Float {
+// powerScale returns the given base unit scale raised to the given power.
+func powerScale(scale number, power int) number {
 	if power == 1 {
 		return scale
 	}
 
-	ret := (&big.Float{}).Copy(scale)
+	ret := scale
 	var absPower int
 	if power < 0 {
 		absPower = -power
@@ -137,15 +246,12 @@ func powerScale(scale *big.Float, power int) *big.Float {
 		absPower = power
 	}
 
-	if absPower > 1 {
-		p := (&big.Float{}).Copy(ret)
-		for i := 1; i < absPower; i++ {
-			ret.Mul(ret, p)
-		}
+	for i := 1; i < absPower; i++ {
+		ret = ret.Mul(scale)
 	}
 
 	if power < 0 {
-		ret.Quo(&one, ret)
+		ret = makeNumberInt(1).Quo(ret)
 	}
 
 	return ret
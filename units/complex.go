@@ -0,0 +1,225 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// ComplexQuantity is a quantity with real and imaginary parts, both
+// expressed in the same unit. Complex quantities are used for AC analysis,
+// such as an impedance Z = R + jX or a voltage phasor.
+//
+// Complex quantities cannot be logarithmic.
+type ComplexQuantity struct {
+	re, im Quantity
+}
+
+// MakeComplexQuantity initializes a ComplexQuantity with the given real and
+// imaginary parts. The imaginary part is converted to the unit of the real
+// part.
+//
+// Will panic if the two quantities are not commensurable, or if either is
+// logarithmic.
+func MakeComplexQuantity(re, im Quantity) ComplexQuantity {
+	if re.unit.Logarithmic() || im.unit.Logarithmic() {
+		panic("attempt to make complex quantity from logarithmic quantities")
+	}
+	if !re.CommensurableWith(im) {
+		panic("attempt to make complex quantity from incommensurable quantities")
+	}
+
+	return ComplexQuantity{
+		re: re,
+		im: im.Convert(re.unit),
+	}
+}
+
+// MakeReal returns a ComplexQuantity whose real part is the given quantity
+// and whose imaginary part is zero.
+func MakeReal(q Quantity) ComplexQuantity {
+	return MakeComplexQuantity(q, MakeQuantityInt(0, q.unit))
+}
+
+// MakeImaginary returns a ComplexQuantity whose imaginary part is the given
+// quantity and whose real part is zero.
+func MakeImaginary(q Quantity) ComplexQuantity {
+	return MakeComplexQuantity(MakeQuantityInt(0, q.unit), q)
+}
+
+// MakePolar returns a ComplexQuantity with the given magnitude and phase,
+// which must be an angle. For example, a voltage phasor of 5 V at 30°.
+//
+// The phase is applied using float64 trigonometry, so the result is not as
+// precise as other quantity arithmetic.
+//
+// Will panic if the phase is not an angle, or if the magnitude is
+// logarithmic.
+func MakePolar(mag, phase Quantity) ComplexQuantity {
+	if phase.unit.dim != (Dimensionality{Angle: 1}) {
+		panic("MakePolar called with non-angle phase")
+	}
+	rad := phase.Convert(unitByName["rad"]).value.Float64()
+	sin, cos := math.Sincos(rad)
+
+	return MakeComplexQuantity(
+		mag.Multiply(MakeDimensionlessFloat(cos)),
+		mag.Multiply(MakeDimensionlessFloat(sin)),
+	)
+}
+
+// Real returns the real part of the receiver.
+func (c ComplexQuantity) Real() Quantity {
+	return c.re
+}
+
+// Imag returns the imaginary part of the receiver.
+func (c ComplexQuantity) Imag() Quantity {
+	return c.im
+}
+
+// Unit returns the unit of both parts of the receiver.
+func (c ComplexQuantity) Unit() *Unit {
+	return c.re.unit
+}
+
+// Abs returns the magnitude of the receiver, in the receiver's unit.
+func (c ComplexQuantity) Abs() Quantity {
+	re, im := c.re.value.Big(), c.im.value.Big()
+	sq := (&big.Float{}).Mul(re, re)
+	sq.Add(sq, (&big.Float{}).Mul(im, im))
+	return MakeQuantity(sq.Sqrt(sq), c.re.unit)
+}
+
+// Phase returns the phase angle of the receiver, in radians between -π
+// and π. The phase of zero is zero.
+func (c ComplexQuantity) Phase() Quantity {
+	rad := math.Atan2(c.im.value.Float64(), c.re.value.Float64())
+	return MakeQuantityFloat(rad, unitByName["rad"])
+}
+
+// Conjugate returns the complex conjugate of the receiver, whose imaginary
+// part has the opposite sign.
+func (c ComplexQuantity) Conjugate() ComplexQuantity {
+	return ComplexQuantity{
+		re: c.re,
+		im: MakeQuantityInt(0, c.im.unit).Subtract(c.im),
+	}
+}
+
+// Convert returns a new ComplexQuantity that is equivalent to the receiver
+// but is expressed in the given unit.
+//
+// Will panic if the receiver's unit is not commensurable with the given
+// unit.
+func (c ComplexQuantity) Convert(new *Unit) ComplexQuantity {
+	return ComplexQuantity{
+		re: c.re.Convert(new),
+		im: c.im.Convert(new),
+	}
+}
+
+// Equal returns true if and only if both parts of the receiver are equal
+// to the corresponding parts of the given quantity.
+func (c ComplexQuantity) Equal(o ComplexQuantity) bool {
+	return c.re.Equal(o.re) && c.im.Equal(o.im)
+}
+
+// Same returns true if and only if both parts of the receiver have the
+// same value and unit as the corresponding parts of the given quantity.
+//
+// This method is primarily provided for testing.
+func (c ComplexQuantity) Same(o ComplexQuantity) bool {
+	return c.re.Same(o.re) && c.im.Same(o.im)
+}
+
+// Add computes the sum of the receiver and the given quantity, which must
+// have commensurable units.
+//
+// If the units are not commensurable, this method will panic.
+func (c ComplexQuantity) Add(o ComplexQuantity) ComplexQuantity {
+	c, o = c.sameUnits(o)
+	return ComplexQuantity{
+		re: c.re.Add(o.re),
+		im: c.im.Add(o.im),
+	}
+}
+
+// Subtract computes the difference between the receiver and the given
+// quantity, which must have commensurable units.
+//
+// If the units are not commensurable, this method will panic.
+func (c ComplexQuantity) Subtract(o ComplexQuantity) ComplexQuantity {
+	c, o = c.sameUnits(o)
+	return ComplexQuantity{
+		re: c.re.Subtract(o.re),
+		im: c.im.Subtract(o.im),
+	}
+}
+
+// Multiply computes the product of the receiver and the given quantity,
+// multiplying both the values and the units as for Quantity.Multiply.
+func (c ComplexQuantity) Multiply(o ComplexQuantity) ComplexQuantity {
+	// (a + jb)(c + jd) = (ac - bd) + j(ad + bc)
+	return ComplexQuantity{
+		re: c.re.Multiply(o.re).Subtract(c.im.Multiply(o.im)),
+		im: c.re.Multiply(o.im).Add(c.im.Multiply(o.re)),
+	}
+}
+
+// Divide computes the quotient of the receiver by the given quantity,
+// dividing both the values and the units as for Quantity.Divide.
+//
+// Will panic if the given quantity is zero.
+func (c ComplexQuantity) Divide(o ComplexQuantity) ComplexQuantity {
+	// (a + jb)/(c + jd) = ((ac + bd) + j(bc - ad)) / (c² + d²)
+	den := o.re.Multiply(o.re).Add(o.im.Multiply(o.im))
+	if den.value.Sign() == 0 {
+		panic("Attempt to Divide by zero complex quantity")
+	}
+	re := c.re.Multiply(o.re).Add(c.im.Multiply(o.im))
+	im := c.im.Multiply(o.re).Subtract(c.re.Multiply(o.im))
+	return ComplexQuantity{
+		re: re.Divide(den),
+		im: im.Divide(den),
+	}
+}
+
+// sameUnits returns the receiver and the given quantity, both converted to
+// standard units if their units differ, in the same way as Quantity.Add
+// would for their parts, so that the results of both parts agree.
+func (c ComplexQuantity) sameUnits(o ComplexQuantity) (ComplexQuantity, ComplexQuantity) {
+	if !c.re.CommensurableWith(o.re) {
+		panic("Attempt to sum non-commensurable complex quantities")
+	}
+	if c.re.unit.SameBaseUnits(o.re.unit) {
+		return c, o
+	}
+	std := c.re.unit.ToStandardUnits()
+	return c.Convert(std), o.Convert(std)
+}
+
+// String returns a compact, human-readable representation of the receiver,
+// such as "50 + 30j ohm".
+//
+// It is primarily intended for debugging and is thus not optimized.
+func (c ComplexQuantity) String() string {
+	sign := "+"
+	im := c.im.value
+	if im.Sign() < 0 {
+		sign = "-"
+		im = number{}.Sub(im)
+	}
+	valStr := fmt.Sprintf("%s %s %sj", c.re.value, sign, im)
+	unitStr := c.re.unit.String()
+
+	if unitStr == "" {
+		return valStr
+	}
+
+	return fmt.Sprintf("%s %s", valStr, unitStr)
+}
+
+func (c ComplexQuantity) GoString() string {
+	return fmt.Sprintf("units.MakeComplexQuantity(%#v, %#v)", c.re, c.im)
+}
@@ -0,0 +1,62 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestComplexQuantityArithmetic(t *testing.T) {
+	z1 := MakeComplexQuantity(q("50", unitByName["ohm"]), q("30", unitByName["ohm"]))
+	z2 := MakeComplexQuantity(q("1", unitByName["kohm"]), q("-20", unitByName["ohm"]))
+	i := MakeImaginary(q("2", unitByName["A"]))
+
+	tests := []struct {
+		Name string
+		Got  ComplexQuantity
+		Want string
+	}{
+		{"make", z1, "50 + 30j ohm"},
+		{"convert imaginary", z2, "1 - 0.02j kohm"},
+		{"real", MakeReal(q("5", unitByName["V"])), "5 + 0j V"},
+		{"conjugate", z1.Conjugate(), "50 - 30j ohm"},
+		{"add", z1.Add(z1), "100 + 60j ohm"},
+		{"add different units", z1.Add(z2), "1050 + 10j ohm"},
+		{"subtract", z1.Subtract(z2), "-950 + 50j ohm"},
+		{"multiply", z1.Multiply(i), "-60 + 100j V"},
+		{"multiply by conjugate", z1.Multiply(z1.Conjugate()), "3400 + 0j kg² m⁴ A⁻⁴ s⁻⁶"},
+		{"divide", z1.Multiply(i).Divide(z1), "0 + 2j A"},
+		{"dimensionless", MakeImaginary(q("1", dimless)).Multiply(MakeImaginary(q("1", dimless))), "-1 + 0j"},
+		{"polar", MakePolar(q("2", unitByName["V"]), q("0", unitByName["deg"])), "2 + 0j V"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if got := test.Got.String(); got != test.Want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestComplexQuantityAbsPhase(t *testing.T) {
+	z := MakeComplexQuantity(q("30", unitByName["ohm"]), q("40", unitByName["ohm"]))
+
+	if got, want := z.Abs().String(), "50 ohm"; got != want {
+		t.Errorf("wrong magnitude\ngot:  %s\nwant: %s", got, want)
+	}
+
+	phase := MakeImaginary(q("1", unitByName["V"])).Phase().Convert(unitByName["deg"])
+	if got, want := phase.String(), "90 deg"; got != want {
+		t.Errorf("wrong phase\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestComplexQuantityDivideByZero(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("did not panic")
+		}
+	}()
+	a := MakeReal(q("1", dimless))
+	b := MakeReal(q("0", dimless))
+	a.Divide(b)
+}
@@ -0,0 +1,92 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// DefineUnit creates a new unit with the given name whose magnitude is the
+// given quantity. For example, a copper weight of one ounce per square foot
+// corresponds to a thickness of 34.79 µm, so it can be defined as:
+//
+//     ozCu, err := units.DefineUnit("oz_cu", units.MakeQuantityFloat(34.79, units.ByName("um")))
+//
+// The new unit behaves as a named unit, but it is not registered for lookup
+// with ByName, or anywhere else, since units defined in Cirbo packages are
+// scoped to the package that defines them. The name is instead carried by
+// the unit itself. Calling DefineUnit twice, whether or not with the same
+// name, produces two distinct (though commensurable) units.
+//
+// A unit of a single base dimension, such as length, may be any multiple of
+// an existing unit. Units of other dimensionalities, including dimensionless
+// units, are limited to power-of-ten multiples of the standard units, since
+// derived units can have only an SI scale factor. An error is returned if
+// the given quantity cannot be used to define a unit.
+func DefineUnit(name string, def Quantity) (*Unit, error) {
+	if def.unit.Logarithmic() {
+		return nil, errors.New("a unit cannot be defined in terms of a logarithmic unit")
+	}
+	if def.value.Sign() <= 0 {
+		return nil, errors.New("a unit must be defined as a positive quantity")
+	}
+	if def.unit.OffsetTemperature() {
+		// An absolute temperature would include the offset of its scale,
+		// which is not what the user intends.
+		return nil, errors.New("a temperature unit must be defined in kelvins")
+	}
+
+	// factor is the number of standard units in one of the new unit.
+	factor := def.WithStandardUnits().value.Big()
+
+	u := &Unit{
+		dim:  def.unit.dim,
+		name: name,
+	}
+	entries := def.unit.dim.dimEntries()
+	if len(entries) == 1 && entries[0].Power == 1 {
+		scale := (&big.Float{}).Quo(&one, factor)
+		switch entries[0].Dimension {
+		case Mass:
+			u.base.Mass = &massUnit{Scale: *scale, name: name}
+			u.base.Mass.initNumbers()
+		case Length:
+			u.base.Length = &lengthUnit{Scale: *scale, name: name}
+			u.base.Length.initNumbers()
+		case Angle:
+			u.base.Angle = &angleUnit{Scale: *scale, name: name}
+			u.base.Angle.initNumbers()
+		case Time:
+			u.base.Time = &timeUnit{Scale: *scale, name: name}
+			u.base.Time.initNumbers()
+		case ElectricCurrent:
+			u.base.ElectricCurrent = &electricCurrentUnit{Scale: *scale, name: name}
+			u.base.ElectricCurrent.initNumbers()
+		case LuminousIntensity:
+			u.base.LuminousIntensity = &luminousIntensityUnit{Scale: *scale, name: name}
+			u.base.LuminousIntensity.initNumbers()
+		case Temperature:
+			u.base.Temperature = &temperatureUnit{Scale: *scale, name: name}
+			u.base.Temperature.initNumbers()
+		default:
+			// should never happen if dimEntries is working correctly
+			panic("DefineUnit called with invalid base dimension entry")
+		}
+		return u, nil
+	}
+
+	f, _ := factor.Float64()
+	exp := log10(f)
+	if exp != math.Trunc(exp) || math.Abs(exp) > 18 {
+		return nil, errors.New("a unit of a derived dimension must be a power-of-ten multiple of the standard unit")
+	}
+
+	u.base = def.unit.ToStandardUnits().base
+	switch {
+	case exp > 0:
+		u.scale = int64(math.Pow10(int(exp)))
+	case exp < 0:
+		u.scale = -int64(math.Pow10(int(-exp)))
+	}
+	return u, nil
+}
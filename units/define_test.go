@@ -0,0 +1,92 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestDefineUnit(t *testing.T) {
+	ozCu, err := DefineUnit("oz_cu", q("34.79", unitByName["um"]))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ppm, err := DefineUnit("ppm", q("0.000001", dimless))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	mW, err := DefineUnit("milliwatt", q("0.001", unitByName["W"]))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	kilowatt, err := DefineUnit("kilowatt", q("1000", unitByName["W"]))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	siemens, err := DefineUnit("siemens", q("1", unitByName["ohm"].Reciprocal()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		Got  Quantity
+		Want string
+	}{
+		{q("2", ozCu), "2 oz_cu"},
+		{q("2", ozCu).Convert(unitByName["um"]), "69.58 um"},
+		{q("1", ozCu).Convert(unitByName["mil"]), "1.369685039 mil"},
+		{q("1", unitByName["mm"]).Convert(ozCu), "28.74389192 oz_cu"},
+		{q("2", ozCu).Multiply(q("3", ozCu)), "6 oz_cu²"},
+		{q("50", ppm).Convert(dimless), "5e-05"},
+		{q("1500", mW).Convert(unitByName["W"]), "1.5 W"},
+		{q("2", kilowatt), "2 kilowatt"},
+		{q("2", unitByName["kW"]).Convert(kilowatt), "2 kilowatt"},
+		{q("2", siemens).Convert(unitByName["ohm"].Reciprocal()), "2 ohm⁻¹"},
+		{q("1", dimless).Divide(q("2", unitByName["ohm"])), "0.5 ohm⁻¹"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Want, func(t *testing.T) {
+			if got := test.Got.String(); got != test.Want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.Want)
+			}
+		})
+	}
+
+	if ByName("oz_cu") != nil {
+		t.Errorf("defined unit is registered globally")
+	}
+	if ByName("kW").String() != "kW" {
+		t.Errorf("defined unit renamed the built-in unit kW")
+	}
+
+	// Each definition is distinct, even with the same magnitude.
+	otherKW, err := DefineUnit("other_kilowatt", q("1000", unitByName["W"]))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if otherKW == kilowatt {
+		t.Errorf("second definition returned the first unit")
+	}
+	if got, want := otherKW.String(), "other_kilowatt"; got != want {
+		t.Errorf("wrong name %q; want %q", got, want)
+	}
+}
+
+func TestDefineUnitErrors(t *testing.T) {
+	tests := []struct {
+		Name string
+		Def  Quantity
+	}{
+		{"zero", q("0", unitByName["mm"])},
+		{"logarithmic", q("1", unitByName["dBm"])},
+		{"offset temperature", q("1", unitByName["degC"])},
+		{"derived non-power-of-ten", q("2.5", unitByName["V"])},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if _, err := DefineUnit("bad", test.Def); err == nil {
+				t.Errorf("no error")
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ const (
 	ElectricCurrent
 	LuminousIntensity
 	Time
+	Temperature
 )
 
 var powerReplacer = strings.NewReplacer(
@@ -50,6 +51,8 @@ func (d BaseDimension) Symbol() string {
 		return "I"
 	case LuminousIntensity:
 		return "J"
+	case Temperature:
+		return "Θ"
 	default:
 		panic("can't call Symbol() on invalid BaseDimension")
 	}
@@ -73,6 +76,7 @@ type Dimensionality struct {
 	Time              int
 	ElectricCurrent   int
 	LuminousIntensity int
+	Temperature       int
 }
 
 // Multiply returns the product of the receiver and the other given
@@ -85,6 +89,7 @@ func (d Dimensionality) Multiply(o Dimensionality) Dimensionality {
 	ret.Time = d.Time + o.Time
 	ret.ElectricCurrent = d.ElectricCurrent + o.ElectricCurrent
 	ret.LuminousIntensity = d.LuminousIntensity + o.LuminousIntensity
+	ret.Temperature = d.Temperature + o.Temperature
 	return ret
 }
 
@@ -97,6 +102,7 @@ func (d Dimensionality) Reciprocal() Dimensionality {
 	ret.Time = -d.Time
 	ret.ElectricCurrent = -d.ElectricCurrent
 	ret.LuminousIntensity = -d.LuminousIntensity
+	ret.Temperature = -d.Temperature
 	return ret
 }
 
@@ -113,6 +119,7 @@ func (d Dimensionality) ToPower(power int) Dimensionality {
 		Time:              d.Time * power,
 		ElectricCurrent:   d.ElectricCurrent * power,
 		LuminousIntensity: d.LuminousIntensity * power,
+		Temperature:       d.Temperature * power,
 	}
 }
 
@@ -137,7 +144,7 @@ func (d Dimensionality) String() string {
 }
 
 func (d Dimensionality) dimEntries() dimEntries {
-	e := make(dimEntries, 0, 6)
+	e := make(dimEntries, 0, 7)
 	if d.Mass != 0 {
 		e = append(e, dimEntry{Mass, d.Mass})
 	}
@@ -156,6 +163,9 @@ func (d Dimensionality) dimEntries() dimEntries {
 	if d.Time != 0 {
 		e = append(e, dimEntry{Time, d.Time})
 	}
+	if d.Temperature != 0 {
+		e = append(e, dimEntry{Temperature, d.Temperature})
+	}
 	sort.Stable(e)
 	return e
 }
@@ -66,6 +66,15 @@ func TestDimensionalityString(t *testing.T) {
 			},
 			"[J]¹²³⁴⁵⁶⁷⁸⁹⁰",
 		},
+		{
+			Dimensionality{
+				Mass:        -1,
+				Length:      -2,
+				Time:        3,
+				Temperature: 1,
+			},
+			"[Θ][T]³[M]⁻¹[L]⁻²",
+		},
 	}
 
 	for i, test := range tests {
@@ -0,0 +1,165 @@
+package units
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FormatOptions customizes the output of Quantity.Format.
+type FormatOptions struct {
+	// Digits is the number of significant digits to include. If zero,
+	// three significant digits are used.
+	Digits int
+
+	// RKM selects the RKM code style defined in IEC 60062, where the
+	// prefix takes the place of the decimal point and the unit is omitted,
+	// such as "4k7" for 4.7 kohm or "100n" for 100 nF. Values with no
+	// prefix use "R" for resistances, such as "4R7", and otherwise the unit
+	// symbol, such as "3V3".
+	RKM bool
+
+	// ASCII restricts the result to ASCII characters, writing "u" for the
+	// micro prefix, "ohm" for the ohm symbol and "degC" for degrees Celsius
+	// rather than "µ", "Ω" and "°C".
+	ASCII bool
+}
+
+// formatUnits are the names of the unprefixed units that Format will use,
+// along with a prefix, to express quantities of their dimensionality.
+//
+// This is a subset of prefixableUnits, since some prefixable units are not
+// the conventional unit for their dimension. Angles, for example, are more
+// commonly written in degrees than in milliradians.
+var formatUnits = []string{
+	"g",
+	"m",
+	"s",
+	"A",
+	"cd",
+	"ohm",
+	"V",
+	"Hz",
+	"N",
+	"W",
+	"F",
+	"C",
+	"H",
+	"lx",
+}
+
+// unitSymbols are the conventional symbols for units whose names are
+// restricted to ASCII, for use when Format is not.
+var unitSymbols = map[string]string{
+	"ohm":  "Ω",
+	"degC": "°C",
+	"degF": "°F",
+}
+
+// Format returns a human-readable representation of the receiver in
+// engineering notation, using whichever SI prefix gives a value between 1
+// and 1000, such as "100 nF", "4.7 kΩ" or "3.3 V".
+//
+// Quantities whose dimensionality has no prefixable SI unit, such as
+// lengths per second or temperatures, are written without a prefix, in
+// their own unit if it has a name or otherwise in standard units.
+//
+// The value is rounded to the number of significant digits given in the
+// options, so the result is intended for display rather than for
+// recovering the exact quantity.
+func (q Quantity) Format(opts FormatOptions) string {
+	digits := opts.Digits
+	if digits <= 0 {
+		digits = 3
+	}
+
+	var unit *Unit
+	for _, name := range formatUnits {
+		if candidate := unitByName[name]; q.unit.CommensurableWith(candidate) {
+			unit = candidate
+			break
+		}
+	}
+	if unit == nil {
+		// Named units, such as degrees Celsius, are kept as-is since their
+		// standard equivalent may not be what the user expects to see.
+		if _, named := unitName[q.unit]; !named && q.unit.name == "" {
+			q = q.WithStandardUnits()
+		}
+		v := q.value.Float64()
+		num := roundDigits(v, digits)
+		unitStr := q.unit.String()
+		if !opts.ASCII && unitSymbols[unitStr] != "" {
+			unitStr = unitSymbols[unitStr]
+		}
+		if unitStr != "" {
+			return num + " " + unitStr
+		}
+		return num
+	}
+
+	v := q.Convert(unit).value.Float64()
+	exp := 0
+	if v != 0 && !math.IsInf(v, 0) && !math.IsNaN(v) {
+		exp = int(math.Floor(math.Log10(math.Abs(v))/3)) * 3
+		if exp < siPrefixes[0].Exp {
+			exp = siPrefixes[0].Exp
+		}
+		if maxExp := siPrefixes[len(siPrefixes)-1].Exp; exp > maxExp {
+			exp = maxExp
+		}
+	}
+
+	num := roundDigits(v/math.Pow10(exp), digits)
+	if m, err := strconv.ParseFloat(num, 64); err == nil && math.Abs(m) >= 1000 && exp < siPrefixes[len(siPrefixes)-1].Exp {
+		// Rounding has carried the value into the next prefix, as with
+		// 999.96 rounding to 1000 at four significant digits.
+		exp += 3
+		num = roundDigits(v/math.Pow10(exp), digits)
+	}
+
+	prefix := ""
+	for _, p := range siPrefixes {
+		if p.Exp == exp {
+			prefix = p.Symbol
+		}
+	}
+	if prefix == "u" && !opts.ASCII {
+		prefix = "µ"
+	}
+
+	symbol := unitName[unit]
+
+	if opts.RKM {
+		marker := prefix
+		if marker == "" {
+			marker = symbol
+			if unit == unitByName["ohm"] {
+				marker = "R"
+			}
+		}
+		if dot := strings.IndexByte(num, '.'); dot >= 0 {
+			return num[:dot] + marker + num[dot+1:]
+		}
+		return num + marker
+	}
+
+	if !opts.ASCII && unitSymbols[symbol] != "" {
+		symbol = unitSymbols[symbol]
+	}
+	return num + " " + prefix + symbol
+}
+
+// roundDigits formats the given value rounded to the given number of
+// significant digits, without an exponent and without trailing zeros after
+// the decimal point.
+func roundDigits(v float64, digits int) string {
+	if v == 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	// Round first using exponent notation, which counts significant digits
+	// for us, and then reformat without the exponent.
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'e', digits-1, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
@@ -0,0 +1,53 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestQuantityFormat(t *testing.T) {
+	tests := []struct {
+		Q    Quantity
+		Opts FormatOptions
+		Want string
+	}{
+		{q("0.0000001", unitByName["F"]), FormatOptions{}, "100 nF"},
+		{q("4700", unitByName["ohm"]), FormatOptions{}, "4.7 kΩ"},
+		{q("4.7", unitByName["kohm"]), FormatOptions{ASCII: true}, "4.7 kohm"},
+		{q("3300", unitByName["mV"]), FormatOptions{}, "3.3 V"},
+		{q("0.00001", unitByName["H"]), FormatOptions{}, "10 µH"},
+		{q("0.00001", unitByName["H"]), FormatOptions{ASCII: true}, "10 uH"},
+		{q("12345", unitByName["Hz"]), FormatOptions{}, "12.3 kHz"},
+		{q("12345", unitByName["Hz"]), FormatOptions{Digits: 5}, "12.345 kHz"},
+		{q("999.96", unitByName["V"]), FormatOptions{Digits: 4}, "1 kV"},
+		{q("-0.025", unitByName["A"]), FormatOptions{}, "-25 mA"},
+		{q("0", unitByName["F"]), FormatOptions{}, "0 F"},
+		{q("2.5", unitByName["kg"]), FormatOptions{}, "2.5 kg"},
+		{q("25.4", unitByName["mm"]), FormatOptions{}, "25.4 mm"},
+		{q("1", unitByName["in"]), FormatOptions{}, "25.4 mm"},
+		{q("0.000000000000001", unitByName["F"]), FormatOptions{}, "0.001 pF"},
+		{q("15000000000", unitByName["Hz"]), FormatOptions{}, "15 GHz"},
+		{q("1.5", dimless), FormatOptions{}, "1.5"},
+		{q("90", unitByName["deg"]), FormatOptions{}, "90 deg"},
+		{q("25", unitByName["degC"]), FormatOptions{}, "25 °C"},
+		{q("25", unitByName["degC"]), FormatOptions{ASCII: true}, "25 degC"},
+		{q("300", unitByName["K"]), FormatOptions{}, "300 K"},
+		{q("3", &Unit{Dimensionality{Length: 1, Time: -1}, baseUnits{Length: meter, Time: second}, 0, ""}), FormatOptions{}, "3 m s⁻¹"},
+
+		{q("4700", unitByName["ohm"]), FormatOptions{RKM: true}, "4k7"},
+		{q("4.7", unitByName["ohm"]), FormatOptions{RKM: true}, "4R7"},
+		{q("10", unitByName["ohm"]), FormatOptions{RKM: true}, "10R"},
+		{q("100", unitByName["nF"]), FormatOptions{RKM: true}, "100n"},
+		{q("2.2", unitByName["uF"]), FormatOptions{RKM: true}, "2µ2"},
+		{q("2.2", unitByName["uF"]), FormatOptions{RKM: true, ASCII: true}, "2u2"},
+		{q("3.3", unitByName["V"]), FormatOptions{RKM: true}, "3V3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Want, func(t *testing.T) {
+			got := test.Q.Format(test.Opts)
+			if got != test.Want {
+				t.Errorf("wrong result\nquant: %s\ngot:   %s\nwant:  %s", test.Q, got, test.Want)
+			}
+		})
+	}
+}
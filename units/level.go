@@ -0,0 +1,158 @@
+package units
+
+import (
+	"math"
+	"math/big"
+)
+
+// levelRef is the base of a logarithmic unit, such as dBm, whose values are
+// levels relative to a reference quantity rather than multiples of a unit.
+//
+// Logarithmic units have the dimensionality of their reference quantity and
+// use the standard units for each of its base dimensions, but they are
+// commensurable only with other logarithmic units. Conversion to and from
+// linear units is explicit, via Quantity.ToLinear and Quantity.ToLevel.
+type levelRef struct {
+	// Factor is 10 for levels of power quantities, such as dBm, and 20 for
+	// levels of root-power quantities, such as dBV.
+	Factor int64
+
+	// Reference is the linear quantity, in standard units, corresponding to
+	// a level of zero.
+	Reference big.Float
+}
+
+var decibel = &levelRef{10, bf("1")}
+var decibelWatt = &levelRef{10, bf("1")}
+var decibelMilliwatt = &levelRef{10, bf("0.001")}
+var decibelVolt = &levelRef{20, bf("1")}
+
+// Logarithmic returns true if the receiver is a logarithmic unit, such as
+// dB or dBm.
+func (u *Unit) Logarithmic() bool {
+	return u.base.Level != nil
+}
+
+// relativeLevel returns true if the receiver is a logarithmic unit with a
+// dimensionless reference, which is to say that it is a gain or a ratio of
+// two levels rather than an absolute level.
+func (u *Unit) relativeLevel() bool {
+	return u.base.Level != nil && u.dim == Dimensionality{}
+}
+
+// ToLinear converts a level in a logarithmic unit into the linear quantity
+// it represents, in standard units. For example, 30 dBm is 1 W.
+//
+// Gains in dB are converted as power ratios, so 20 dB is a ratio of 100.
+//
+// Will panic if the receiver's unit is not logarithmic.
+func (q Quantity) ToLinear() Quantity {
+	level := q.unit.base.Level
+	if level == nil {
+		panic("ToLinear called on linear quantity")
+	}
+
+	v := q.value.Float64()
+	nf := big.NewFloat(math.Pow(10, v/float64(level.Factor)))
+	nf.Mul(nf, &level.Reference)
+
+	unit := &Unit{dim: q.unit.dim, base: q.unit.base}
+	unit.base.Level = nil
+	return MakeQuantity(nf, unit.normalize())
+}
+
+// ToLevel converts a linear quantity into a level in the given logarithmic
+// unit. For example, 1 W is 30 dBm.
+//
+// Will panic if the given unit is not logarithmic, if its reference is not
+// commensurable with the receiver, or if the receiver is not positive.
+func (q Quantity) ToLevel(unit *Unit) Quantity {
+	level := unit.base.Level
+	switch {
+	case level == nil:
+		panic("ToLevel called with linear unit")
+	case q.unit.base.Level != nil:
+		panic("ToLevel called on logarithmic quantity")
+	case q.unit.dim != unit.dim:
+		panic("ToLevel called with incommensurable unit")
+	case q.value.Sign() <= 0:
+		panic("ToLevel called on non-positive quantity")
+	}
+
+	ratio := q.WithStandardUnits().Value()
+	ratio.Quo(ratio, &level.Reference)
+	r, _ := ratio.Float64()
+
+	nf := big.NewFloat(float64(level.Factor) * log10(r))
+	return MakeQuantity(nf, unit)
+}
+
+// convertLevel is the implementation of Convert for logarithmic units.
+func (q Quantity) convertLevel(new *Unit) Quantity {
+	old := q.unit
+	if old.base.Level == nil || new.base.Level == nil || old.dim != new.dim {
+		panic("attempt to convert between logarithmic and linear units")
+	}
+
+	// Both levels have the same dimensionality, so they also have the same
+	// factor and we need only account for the different references.
+	ratio := (&big.Float{}).Quo(&old.base.Level.Reference, &new.base.Level.Reference)
+	r, _ := ratio.Float64()
+
+	nf := q.Value()
+	nf.Add(nf, big.NewFloat(float64(old.base.Level.Factor)*log10(r)))
+	return MakeQuantity(nf, new)
+}
+
+// sumLevels is the implementation of Add and Subtract for logarithmic units.
+//
+// A gain can be added to or subtracted from a level, producing another level,
+// and the difference between two levels is a gain. Adding two levels is
+// meaningless, as is any combination of logarithmic and linear units.
+func sumLevels(a, b Quantity, subtract bool) Quantity {
+	if a.unit.base.Level == nil || b.unit.base.Level == nil {
+		panic("Attempt to sum logarithmic and linear quantities")
+	}
+
+	aRel, bRel := a.unit.relativeLevel(), b.unit.relativeLevel()
+	unit := a.unit
+	switch {
+	case aRel && !bRel:
+		if subtract {
+			panic("Attempt to Subtract a level from a gain")
+		}
+		unit = b.unit
+	case !aRel && !bRel:
+		if !subtract {
+			panic("Attempt to Add two levels")
+		}
+		if a.unit.dim != b.unit.dim {
+			panic("Attempt to Subtract non-commensurable levels")
+		}
+		b = b.Convert(a.unit)
+		unit = unitByName["dB"]
+	}
+
+	var nv number
+	if subtract {
+		nv = a.value.Sub(b.value)
+	} else {
+		nv = a.value.Add(b.value)
+	}
+
+	return Quantity{
+		unit:  unit,
+		value: nv,
+	}
+}
+
+// log10 is math.Log10, except that exact powers of ten produce exact
+// integer results, so that levels such as 30 dBm are not subject to
+// rounding error.
+func log10(v float64) float64 {
+	l := math.Log10(v)
+	if rl := math.Round(l); math.Pow10(int(rl)) == v {
+		return rl
+	}
+	return l
+}
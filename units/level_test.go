@@ -0,0 +1,141 @@
+package units
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestQuantityToLinear(t *testing.T) {
+	tests := []struct {
+		Q    Quantity
+		Want string
+	}{
+		{q("30", unitByName["dBm"]), "1 W"},
+		{q("0", unitByName["dBm"]), "0.001 W"},
+		{q("-10", unitByName["dBW"]), "0.1 W"},
+		{q("20", unitByName["dBV"]), "10 V"},
+		{q("20", unitByName["dB"]), "100"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Q.String(), func(t *testing.T) {
+			got := test.Q.ToLinear()
+			if gotStr := got.String(); gotStr != test.Want {
+				t.Errorf("wrong result\ninput: %s\ngot:   %s\nwant:  %s", test.Q, gotStr, test.Want)
+			}
+		})
+	}
+}
+
+func TestQuantityToLevel(t *testing.T) {
+	tests := []struct {
+		Q    Quantity
+		Unit *Unit
+		Want string
+	}{
+		{q("1", unitByName["W"]), unitByName["dBm"], "30 dBm"},
+		{q("1", unitByName["mW"]), unitByName["dBm"], "0 dBm"},
+		{q("100", unitByName["mW"]), unitByName["dBW"], "-10 dBW"},
+		{q("10", unitByName["V"]), unitByName["dBV"], "20 dBV"},
+		{q("100", unitByName["mV"]), unitByName["dBV"], "-20 dBV"},
+		{q("1000", dimless), unitByName["dB"], "30 dB"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s in %s", test.Q, test.Unit), func(t *testing.T) {
+			got := test.Q.ToLevel(test.Unit)
+			if gotStr := got.String(); gotStr != test.Want {
+				t.Errorf("wrong result\ninput: %s\ngot:   %s\nwant:  %s", test.Q, gotStr, test.Want)
+			}
+		})
+	}
+}
+
+func TestQuantityLevelArithmetic(t *testing.T) {
+	tests := []struct {
+		A        Quantity
+		B        Quantity
+		Subtract bool
+		Want     string
+	}{
+		{q("10", unitByName["dBm"]), q("3", unitByName["dB"]), false, "13 dBm"},
+		{q("3", unitByName["dB"]), q("10", unitByName["dBm"]), false, "13 dBm"},
+		{q("10", unitByName["dBm"]), q("3", unitByName["dB"]), true, "7 dBm"},
+		{q("20", unitByName["dB"]), q("3", unitByName["dB"]), false, "23 dB"},
+		{q("13", unitByName["dBm"]), q("10", unitByName["dBm"]), true, "3 dB"},
+		{q("0", unitByName["dBW"]), q("10", unitByName["dBm"]), true, "20 dB"},
+		{q("6", unitByName["dBV"]), q("-6", unitByName["dB"]), false, "0 dBV"},
+	}
+
+	for _, test := range tests {
+		op := "+"
+		if test.Subtract {
+			op = "-"
+		}
+		t.Run(fmt.Sprintf("%s %s %s", test.A, op, test.B), func(t *testing.T) {
+			var got Quantity
+			if test.Subtract {
+				got = test.A.Subtract(test.B)
+			} else {
+				got = test.A.Add(test.B)
+			}
+			if gotStr := got.String(); gotStr != test.Want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", gotStr, test.Want)
+			}
+		})
+	}
+}
+
+func TestQuantityLevelMeaningless(t *testing.T) {
+	tests := map[string]func(){
+		"dBm + dBm": func() {
+			q("10", unitByName["dBm"]).Add(q("10", unitByName["dBm"]))
+		},
+		"dB - dBm": func() {
+			q("3", unitByName["dB"]).Subtract(q("10", unitByName["dBm"]))
+		},
+		"dBm + W": func() {
+			q("10", unitByName["dBm"]).Add(q("1", unitByName["W"]))
+		},
+		"dB + 1": func() {
+			q("3", unitByName["dB"]).Add(q("1", dimless))
+		},
+		"dBm - dBV": func() {
+			q("10", unitByName["dBm"]).Subtract(q("10", unitByName["dBV"]))
+		},
+		"dB * dB": func() {
+			q("3", unitByName["dB"]).Multiply(q("3", unitByName["dB"]))
+		},
+		"dBm to W": func() {
+			q("10", unitByName["dBm"]).Convert(unitByName["W"])
+		},
+	}
+
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("did not panic")
+				}
+			}()
+			f()
+		})
+	}
+}
+
+func TestQuantityLevelConvert(t *testing.T) {
+	got := q("10", unitByName["dBm"]).Convert(unitByName["dBW"])
+	if want := "-20 dBW"; got.String() != want {
+		t.Errorf("wrong result\ngot:  %s\nwant: %s", got, want)
+	}
+
+	if !q("30", unitByName["dBm"]).Equal(q("0", unitByName["dBW"])) {
+		t.Errorf("30 dBm does not equal 0 dBW")
+	}
+	if q("30", unitByName["dBm"]).Equal(q("1", unitByName["W"])) {
+		t.Errorf("30 dBm equals 1 W, but levels should not be commensurable with linear quantities")
+	}
+	if q("0", unitByName["dB"]).CommensurableWith(q("1", dimless)) {
+		t.Errorf("0 dB is commensurable with 1")
+	}
+}
@@ -0,0 +1,269 @@
+package units
+
+import (
+	"math"
+	"math/big"
+)
+
+// number is the value of a quantity.
+//
+// Most values that appear in real designs are small integers or other
+// values that a float64 can represent exactly, and arithmetic on them often
+// produces exact results too. A number therefore holds its value as a
+// float64 for as long as that is exact, and switches to a big.Float only
+// once an operation produces a result that a float64 cannot represent.
+//
+// Since the fast path is taken only for exact results, the arithmetic
+// methods produce the same values as they would if performed entirely with
+// big.Float, but without its allocations in the common case.
+//
+// The zero value of number is zero. A number is immutable: none of its
+// methods modify its big.Float, so a number can share a big.Float with
+// other numbers or with a base unit scale.
+type number struct {
+	// If b is nil then the value is exactly f. Otherwise, the value is b,
+	// and f is also exactly the value if exact is set.
+	f     float64
+	b     *big.Float
+	exact bool
+}
+
+// bigPrec is the precision of the big.Float that a number produces when
+// it falls back from its float64 representation. It matches the default
+// precision used by big.Float.Parse.
+const bigPrec = 64
+
+// fastMin and fastMax bound the magnitudes of the float64 values that take
+// the fast path. Inside this range the error-free transformations used to
+// detect inexact results cannot underflow or overflow, so we don't need to
+// deal with the edge cases of subnormal and infinite values.
+const fastMin = 0x1p-900
+const fastMax = 0x1p900
+
+// makeNumber returns a number with the same value as the given big.Float,
+// which must not be modified afterwards.
+func makeNumber(v *big.Float) number {
+	if f, acc := v.Float64(); acc == big.Exact && fastRange(f) {
+		// We keep v too, so that the slow path need not convert f back
+		// into a big.Float.
+		return number{f: f, b: v, exact: true}
+	}
+	return number{b: v}
+}
+
+// makeNumberInt returns a number with the same value as the given integer.
+func makeNumberInt(v int64) number {
+	if f := float64(v); int64(f) == v && fastRange(f) {
+		return number{f: f}
+	}
+	return number{b: (&big.Float{}).SetInt64(v)}
+}
+
+// fastRange returns true if the given value can take the fast path.
+func fastRange(f float64) bool {
+	if f == 0 {
+		return true
+	}
+	a := math.Abs(f)
+	return a >= fastMin && a <= fastMax
+}
+
+// fast returns true if the receiver's value is exactly its f field.
+func (n number) fast() bool {
+	return n.b == nil || n.exact
+}
+
+// Big returns the value of the receiver as a big.Float, which the caller
+// must not modify.
+func (n number) Big() *big.Float {
+	if n.b != nil {
+		return n.b
+	}
+	return (&big.Float{}).SetPrec(bigPrec).SetFloat64(n.f)
+}
+
+// bigIn is like Big, but uses the given storage if the receiver does not
+// already have a big.Float, to avoid allocating a temporary value.
+func (n number) bigIn(z *big.Float) *big.Float {
+	if n.b != nil {
+		return n.b
+	}
+	return z.SetPrec(bigPrec).SetFloat64(n.f)
+}
+
+// operands prepares for a big.Float operation on the given numbers. It
+// returns a new big.Float for the result along with the two operands,
+// either of which may be the result itself if its number has no big.Float,
+// and the other of which may be stored in the given temporary value.
+func operands(n, o number, tmp *big.Float) (z, x, y *big.Float) {
+	z = &big.Float{}
+	switch {
+	case n.b != nil && o.b != nil:
+		return z, n.b, o.b
+	case n.b != nil:
+		return z.SetPrec(maxPrec(n.b)).SetFloat64(o.f), n.b, z
+	case o.b != nil:
+		return z.SetPrec(maxPrec(o.b)).SetFloat64(n.f), z, o.b
+	default:
+		return z.SetPrec(bigPrec).SetFloat64(n.f), z, o.bigIn(tmp)
+	}
+}
+
+// maxPrec returns the precision for the result of an operation on the
+// given big.Float and a value converted from a float64.
+func maxPrec(v *big.Float) uint {
+	if p := v.Prec(); p > bigPrec {
+		return p
+	}
+	return bigPrec
+}
+
+// Float64 returns the nearest float64 to the receiver's value.
+func (n number) Float64() float64 {
+	if !n.fast() {
+		f, _ := n.b.Float64()
+		return f
+	}
+	return n.f
+}
+
+// Sign returns -1, 0, or 1 depending on the sign of the receiver.
+func (n number) Sign() int {
+	if !n.fast() {
+		return n.b.Sign()
+	}
+	switch {
+	case n.f < 0:
+		return -1
+	case n.f > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Cmp returns -1, 0, or 1 depending on whether the receiver is less than,
+// equal to, or greater than the given number.
+func (n number) Cmp(o number) int {
+	if !n.fast() || !o.fast() {
+		var x, y big.Float
+		return n.bigIn(&x).Cmp(o.bigIn(&y))
+	}
+	switch {
+	case n.f < o.f:
+		return -1
+	case n.f > o.f:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Add returns the sum of the receiver and the given number.
+func (n number) Add(o number) number {
+	if n.fast() && o.fast() {
+		// Knuth's TwoSum gives the exact rounding error of the sum.
+		s := n.f + o.f
+		bb := s - n.f
+		err := (n.f - (s - bb)) + (o.f - bb)
+		if err == 0 && fastRange(s) {
+			return number{f: s}
+		}
+	}
+	var tmp big.Float
+	z, x, y := operands(n, o, &tmp)
+	return number{b: z.Add(x, y)}
+}
+
+// Sub returns the difference between the receiver and the given number.
+func (n number) Sub(o number) number {
+	if n.fast() && o.fast() {
+		return n.Add(number{f: -o.f})
+	}
+	var tmp big.Float
+	z, x, y := operands(n, o, &tmp)
+	return number{b: z.Sub(x, y)}
+}
+
+// Mul returns the product of the receiver and the given number.
+func (n number) Mul(o number) number {
+	if n.fast() && o.fast() {
+		p := n.f * o.f
+		if fastRange(p) && (p != 0 || n.f == 0 || o.f == 0) && math.FMA(n.f, o.f, -p) == 0 {
+			return number{f: p}
+		}
+	}
+	var tmp big.Float
+	z, x, y := operands(n, o, &tmp)
+	return number{b: z.Mul(x, y)}
+}
+
+// Quo returns the quotient of the receiver and the given number.
+//
+// As with big.Float, this panics if both numbers are zero.
+func (n number) Quo(o number) number {
+	if n.fast() && o.fast() && o.f != 0 {
+		q := n.f / o.f
+		if fastRange(q) && (q != 0 || n.f == 0) && math.FMA(q, o.f, -n.f) == 0 {
+			return number{f: q}
+		}
+	}
+	var tmp big.Float
+	z, x, y := operands(n, o, &tmp)
+	return number{b: z.Quo(x, y)}
+}
+
+// String returns a representation of the receiver in the same format as
+// big.Float.String.
+func (n number) String() string {
+	return n.Big().String()
+}
+
+// accumulator applies a sequence of operations to a number, such as the
+// steps of a unit conversion. It takes the fast path for as long as it can,
+// and then continues in place on a single big.Float of its own, rather than
+// allocating a new big.Float for each step.
+type accumulator struct {
+	n     number
+	owned bool // true if n.b was allocated by the accumulator
+}
+
+func (a *accumulator) Add(o number) {
+	if a.owned {
+		var y big.Float
+		a.n.b.Add(a.n.b, o.bigIn(&y))
+		return
+	}
+	a.n = a.n.Add(o)
+	a.owned = !a.n.fast()
+}
+
+func (a *accumulator) Sub(o number) {
+	if a.owned {
+		var y big.Float
+		a.n.b.Sub(a.n.b, o.bigIn(&y))
+		return
+	}
+	a.n = a.n.Sub(o)
+	a.owned = !a.n.fast()
+}
+
+func (a *accumulator) Mul(o number) {
+	if a.owned {
+		var y big.Float
+		a.n.b.Mul(a.n.b, o.bigIn(&y))
+		return
+	}
+	a.n = a.n.Mul(o)
+	a.owned = !a.n.fast()
+}
+
+func (a *accumulator) Quo(o number) {
+	if a.owned {
+		var y big.Float
+		a.n.b.Quo(a.n.b, o.bigIn(&y))
+		return
+	}
+	a.n = a.n.Quo(o)
+	a.owned = !a.n.fast()
+}
@@ -0,0 +1,100 @@
+package units
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNumberArithmetic(t *testing.T) {
+	tests := []struct {
+		A, B string
+		Fast bool // true if A and B are exact float64 values
+	}{
+		{"1", "2", true},
+		{"1500", "1000", true},
+		{"4.7", "1000", false},
+		{"0.5", "0.25", true},
+		{"3", "7", true},
+		{"-2", "3", true},
+		{"0", "5", true},
+		{"1e300", "1e300", false},
+		{"1e-300", "3", false},
+		{"39.370078740157480314960629921259842519685039370079", "25.4", false},
+		{"9007199254740993", "1", false},
+	}
+
+	ops := []struct {
+		Name string
+		Num  func(a, b number) number
+		Big  func(z, a, b *big.Float) *big.Float
+	}{
+		{"Add", number.Add, (*big.Float).Add},
+		{"Sub", number.Sub, (*big.Float).Sub},
+		{"Mul", number.Mul, (*big.Float).Mul},
+		{"Quo", number.Quo, (*big.Float).Quo},
+	}
+
+	for _, test := range tests {
+		a, b := bfp(test.A), bfp(test.B)
+		na, nb := makeNumber(a), makeNumber(b)
+		if got := na.fast() && nb.fast(); got != test.Fast {
+			t.Errorf("%s and %s: fast is %#v; want %#v", test.A, test.B, got, test.Fast)
+		}
+
+		for _, op := range ops {
+			t.Run(op.Name+" "+test.A+" "+test.B, func(t *testing.T) {
+				got := op.Num(na, nb)
+				want := op.Big(&big.Float{}, a, b)
+				if got.Big().Cmp(want) != 0 {
+					t.Errorf("wrong result\ngot:  %s\nwant: %s", got.Big().Text('g', 30), want.Text('g', 30))
+				}
+				if got.fast() && !fastRange(got.f) {
+					t.Errorf("fast result %g is out of range", got.f)
+				}
+			})
+		}
+	}
+}
+
+func TestNumberFastPath(t *testing.T) {
+	tests := []struct {
+		Name string
+		Got  number
+		Fast bool
+	}{
+		{"exact sum", makeNumberInt(2).Add(makeNumberInt(3)), true},
+		{"exact quotient", makeNumberInt(1500).Quo(makeNumberInt(1000)), true},
+		{"inexact quotient", makeNumberInt(1).Quo(makeNumberInt(3)), false},
+		{"inexact sum", number{f: 0.1}.Add(number{f: 0.2}), false},
+		{"overflow", number{f: 1e300}.Mul(number{f: 1e300}), false},
+		{"underflow", number{f: 1e-300}.Mul(number{f: 1e-300}), false},
+		{"large integer", makeNumberInt(math.MaxInt64), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if got := test.Got.fast(); got != test.Fast {
+				t.Errorf("fast is %#v; want %#v", got, test.Fast)
+			}
+		})
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	shared := bfp("4.7")
+	acc := accumulator{n: makeNumber(shared)}
+	acc.Mul(makeNumberInt(1000))
+	acc.Quo(makeNumberInt(10))
+	acc.Add(makeNumberInt(30))
+
+	if got, want := acc.n.String(), "500"; got != want {
+		t.Errorf("wrong result %s; want %s", got, want)
+	}
+	if !acc.owned {
+		t.Errorf("accumulator did not fall back to its own big.Float")
+	}
+	if got, want := shared.String(), "4.7"; got != want {
+		t.Errorf("accumulator modified its initial value to %s", got)
+	}
+}
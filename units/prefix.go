@@ -0,0 +1,167 @@
+package units
+
+import (
+	"math/big"
+)
+
+// siPrefix is one of the SI prefixes that can be applied to a unit symbol
+// to produce a scaled version of that unit.
+type siPrefix struct {
+	Symbol string
+	Exp    int
+}
+
+// siPrefixes are the SI prefixes that can be applied to any of the
+// prefixableUnits.
+var siPrefixes = []siPrefix{
+	{"p", -12},
+	{"n", -9},
+	{"u", -6},
+	{"m", -3},
+	{"k", 3},
+	{"M", 6},
+	{"G", 9},
+}
+
+// siPrefixAliases are alternative symbols for the SI prefixes, which are
+// accepted by ByName but never produced in unit names. The micro sign and
+// the Greek letter mu are visually identical but are distinct characters,
+// so we accept both.
+var siPrefixAliases = map[string]string{
+	"µ": "u", // MICRO SIGN
+	"μ": "u", // GREEK SMALL LETTER MU
+}
+
+// prefixableUnits are the names of the units that can be combined with the
+// SI prefixes. Non-SI units, such as inches and pounds, are not prefixable.
+var prefixableUnits = []string{
+	"g",
+	"m",
+	"rad",
+	"s",
+	"A",
+	"cd",
+	"ohm",
+	"V",
+	"Hz",
+	"N",
+	"W",
+	"F",
+	"C",
+	"H",
+	"lx",
+	"K",
+}
+
+// addPrefixedUnits adds to unitByName every combination of SI prefix and
+// prefixable unit that is not already present.
+//
+// Prefixed versions of the units of the base dimensions, such as "ns",
+// are given their own base units so that they behave in the same way as
+// the hand-written base units such as "ms". Prefixed versions of derived
+// units, such as "nF", are instead represented as scaled units.
+func addPrefixedUnits() {
+	for _, name := range prefixableUnits {
+		unit := unitByName[name]
+		for _, prefix := range siPrefixes {
+			pName := prefix.Symbol + name
+			if _, exists := unitByName[pName]; exists {
+				continue
+			}
+			unitByName[pName] = prefixedUnit(unit, prefix)
+		}
+	}
+}
+
+// addPrefixAliases adds to unitByName an entry for each of the alternative
+// prefix symbols for each prefixed unit. It must be called only after the
+// unitName table is built, so that the aliases are not used as names.
+func addPrefixAliases() {
+	for alias, canon := range siPrefixAliases {
+		for _, name := range prefixableUnits {
+			unitByName[alias+name] = unitByName[canon+name]
+		}
+	}
+}
+
+func prefixedUnit(unit *Unit, prefix siPrefix) *Unit {
+	nu := *unit
+
+	exp := prefix.Exp
+	if exp < 0 {
+		exp = -exp
+	}
+
+	// scaleBase produces the scale of a prefixed base unit from the scale
+	// of the unprefixed unit, which is the number of units per standard
+	// unit. We multiply or divide by an exact power of ten, rather than
+	// multiplying by an inexact power like 1e-3, to preserve precision.
+	pow := (&big.Float{}).SetInt64(1)
+	for i := 0; i < exp; i++ {
+		pow.Mul(pow, big.NewFloat(10))
+	}
+	scaleBase := func(dst, src *big.Float) {
+		if prefix.Exp < 0 {
+			dst.Mul(src, pow)
+		} else {
+			dst.Quo(src, pow)
+		}
+	}
+
+	switch unit.dim {
+	case Dimensionality{Mass: 1}:
+		bu := &massUnit{}
+		scaleBase(&bu.Scale, &unit.base.Mass.Scale)
+		bu.initNumbers()
+		nu.base.Mass = bu
+		massUnits[bu] = &nu
+	case Dimensionality{Length: 1}:
+		bu := &lengthUnit{}
+		scaleBase(&bu.Scale, &unit.base.Length.Scale)
+		bu.initNumbers()
+		nu.base.Length = bu
+		lengthUnits[bu] = &nu
+	case Dimensionality{Angle: 1}:
+		bu := &angleUnit{}
+		scaleBase(&bu.Scale, &unit.base.Angle.Scale)
+		bu.initNumbers()
+		nu.base.Angle = bu
+		angleUnits[bu] = &nu
+	case Dimensionality{Time: 1}:
+		bu := &timeUnit{}
+		scaleBase(&bu.Scale, &unit.base.Time.Scale)
+		bu.initNumbers()
+		nu.base.Time = bu
+		timeUnits[bu] = &nu
+	case Dimensionality{ElectricCurrent: 1}:
+		bu := &electricCurrentUnit{}
+		scaleBase(&bu.Scale, &unit.base.ElectricCurrent.Scale)
+		bu.initNumbers()
+		nu.base.ElectricCurrent = bu
+		electricCurrentUnits[bu] = &nu
+	case Dimensionality{LuminousIntensity: 1}:
+		bu := &luminousIntensityUnit{}
+		scaleBase(&bu.Scale, &unit.base.LuminousIntensity.Scale)
+		bu.initNumbers()
+		nu.base.LuminousIntensity = bu
+		luminousIntensityUnits[bu] = &nu
+	case Dimensionality{Temperature: 1}:
+		// Only kelvins are prefixable, so there is no offset to carry over.
+		bu := &temperatureUnit{}
+		scaleBase(&bu.Scale, &unit.base.Temperature.Scale)
+		bu.initNumbers()
+		nu.base.Temperature = bu
+		temperatureUnits[bu] = &nu
+	default:
+		var scale int64 = 1
+		for i := 0; i < exp; i++ {
+			scale *= 10
+		}
+		if prefix.Exp < 0 {
+			scale = -scale
+		}
+		nu.scale = scale
+	}
+
+	return &nu
+}
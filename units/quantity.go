@@ -7,7 +7,7 @@ import (
 
 // Quantity is the combination of a value and a unit.
 type Quantity struct {
-	value *big.Float
+	value number
 	unit  *Unit
 }
 
@@ -17,27 +17,42 @@ func MakeQuantity(value *big.Float, unit *Unit) Quantity {
 		unit = dimless
 	}
 	return Quantity{
-		value: value,
+		value: makeNumber(value),
 		unit:  unit,
 	}
 }
 
 // MakeQuantityInt is a convenience wrapper around MakeQuantity that accepts
-// an int64 and converts it to a big.Float.
+// an int64.
 func MakeQuantityInt(value int64, unit *Unit) Quantity {
-	return MakeQuantity((&big.Float{}).SetInt64(value), unit)
+	if unit == nil {
+		unit = dimless
+	}
+	return Quantity{
+		value: makeNumberInt(value),
+		unit:  unit,
+	}
 }
 
 // MakeQuantityFloat is a convenience wrapper around MakeQuantity that accepts
-// a float64 and converts it to a big.Float.
+// a float64.
 func MakeQuantityFloat(value float64, unit *Unit) Quantity {
-	return MakeQuantity((&big.Float{}).SetFloat64(value), unit)
+	if !fastRange(value) {
+		return MakeQuantity((&big.Float{}).SetFloat64(value), unit)
+	}
+	if unit == nil {
+		unit = dimless
+	}
+	return Quantity{
+		value: number{f: value},
+		unit:  unit,
+	}
 }
 
 // MakeDimensionless initializes a dimensionless Quantity with the given value
 func MakeDimensionless(value *big.Float) Quantity {
 	return Quantity{
-		value: value,
+		value: makeNumber(value),
 		unit:  dimless,
 	}
 }
@@ -58,7 +73,7 @@ func MakeDimensionlessFloat(value float64) Quantity {
 func (q Quantity) Value() *big.Float {
 	// Since big floats are mutable, we return a copy to prevent
 	// the caller from altering our internal state.
-	return (&big.Float{}).Copy(q.value)
+	return (&big.Float{}).Copy(q.value.Big())
 }
 
 // Value returns the unit of the receiving quantity.
@@ -87,9 +102,11 @@ func (q Quantity) ConvertableTo(new *Unit) bool {
 // is expressed in the given unit.
 //
 // Will panic if the receiver's unit is not commensurable with the
-// given unit. Use ConvertableTo before calling if unsure.
+// given unit. Use ConvertableTo before calling if unsure. In particular,
+// logarithmic units can be converted only to other logarithmic units; use
+// ToLinear and ToLevel to convert between logarithmic and linear units.
 func (q Quantity) Convert(new *Unit) Quantity {
-	nf := q.Value() // creates a copy, so we can mutate
+	nf := accumulator{n: q.value}
 	old := q.Unit()
 
 	if new == old {
@@ -100,55 +117,71 @@ func (q Quantity) Convert(new *Unit) Quantity {
 		return q
 	}
 
+	if old.Logarithmic() || new.Logarithmic() {
+		return q.convertLevel(new)
+	}
+
 	// Eliminate the scale before we begin, so we only have to worry
 	// about the base units.
 	switch {
 	case old.scale > 0:
-		fs := (&big.Float{}).SetInt64(old.scale)
-		nf.Mul(nf, fs)
+		nf.Mul(makeNumberInt(old.scale))
 	case old.scale < 0:
-		fs := (&big.Float{}).SetInt64(-old.scale)
-		nf.Quo(nf, fs)
+		nf.Quo(makeNumberInt(-old.scale))
 	}
 
 	// Now for each base dimension we'll convert to the primary unit and
 	// then to the target, unless units already match.
 	if old.base.Mass != new.base.Mass {
-		nf.Quo(nf, powerScale(&old.base.Mass.Scale, old.dim.Mass))
-		nf.Mul(nf, powerScale(&new.base.Mass.Scale, new.dim.Mass))
+		nf.Quo(powerScale(old.base.Mass.num, old.dim.Mass))
+		nf.Mul(powerScale(new.base.Mass.num, new.dim.Mass))
 	}
 	if old.base.Length != new.base.Length {
-		nf.Quo(nf, powerScale(&old.base.Length.Scale, old.dim.Length))
-		nf.Mul(nf, powerScale(&new.base.Length.Scale, new.dim.Length))
+		nf.Quo(powerScale(old.base.Length.num, old.dim.Length))
+		nf.Mul(powerScale(new.base.Length.num, new.dim.Length))
 	}
 	if old.base.Angle != new.base.Angle {
-		nf.Quo(nf, &old.base.Angle.Scale)
-		nf.Mul(nf, &new.base.Angle.Scale)
+		nf.Quo(old.base.Angle.num)
+		nf.Mul(new.base.Angle.num)
 	}
 	if old.base.Time != new.base.Time {
-		nf.Quo(nf, &old.base.Time.Scale)
-		nf.Mul(nf, &new.base.Time.Scale)
+		nf.Quo(old.base.Time.num)
+		nf.Mul(new.base.Time.num)
 	}
 	if old.base.ElectricCurrent != new.base.ElectricCurrent {
-		nf.Quo(nf, &old.base.ElectricCurrent.Scale)
-		nf.Mul(nf, &new.base.ElectricCurrent.Scale)
+		nf.Quo(old.base.ElectricCurrent.num)
+		nf.Mul(new.base.ElectricCurrent.num)
 	}
 	if old.base.LuminousIntensity != new.base.LuminousIntensity {
-		nf.Quo(nf, &old.base.LuminousIntensity.Scale)
-		nf.Mul(nf, &new.base.LuminousIntensity.Scale)
+		nf.Quo(old.base.LuminousIntensity.num)
+		nf.Mul(new.base.LuminousIntensity.num)
+	}
+	if old.base.Temperature != new.base.Temperature {
+		// Only absolute temperatures are offset; in all other
+		// dimensionalities the temperature is a difference.
+		absolute := old.dim == Dimensionality{Temperature: 1}
+		if absolute {
+			nf.Sub(old.base.Temperature.offsetNum)
+		}
+		nf.Quo(powerScale(old.base.Temperature.scaleNum, old.dim.Temperature))
+		nf.Mul(powerScale(new.base.Temperature.scaleNum, new.dim.Temperature))
+		if absolute {
+			nf.Add(new.base.Temperature.offsetNum)
+		}
 	}
 
 	// Finally, apply any scale required by the new unit.
 	switch {
 	case new.scale > 0:
-		fs := (&big.Float{}).SetInt64(new.scale)
-		nf.Quo(nf, fs)
+		nf.Quo(makeNumberInt(new.scale))
 	case new.scale < 0:
-		fs := (&big.Float{}).SetInt64(-new.scale)
-		nf.Mul(nf, fs)
+		nf.Mul(makeNumberInt(-new.scale))
 	}
 
-	return MakeQuantity(nf, new)
+	return Quantity{
+		value: nf.n,
+		unit:  new,
+	}
 }
 
 // WithStandardUnits converts the quantity so it uses the standard units for
@@ -160,6 +193,7 @@ func (q Quantity) Convert(new *Unit) Quantity {
 //    time                 s       (seconds)
 //    electric current     A       (amps)
 //    luminous intensity   cd      (candelas)
+//    temperature          K       (kelvins)
 func (q Quantity) WithStandardUnits() Quantity {
 	u := q.unit.ToStandardUnits()
 	return q.Convert(u)
@@ -177,7 +211,7 @@ func (q Quantity) WithStandardUnits() Quantity {
 // unlimited, so mismatches may occur when comparing quantities whose values
 // have many significant figures.
 func (q Quantity) Equal(o Quantity) bool {
-	if q.unit.dim != o.unit.dim {
+	if !q.CommensurableWith(o) {
 		return false
 	}
 
@@ -215,11 +249,13 @@ func (q Quantity) Same(o Quantity) bool {
 //      0 if q == 0
 //      1 if q > o
 func (q Quantity) Compare(o Quantity) int {
-	if q.unit.dim != o.unit.dim {
+	if !q.CommensurableWith(o) {
 		panic(fmt.Errorf("attempt to compare incommensurable quantities"))
 	}
 
-	if !q.unit.SameBaseUnits(o.unit) {
+	if q.unit.Logarithmic() {
+		o = o.Convert(q.unit)
+	} else if !q.unit.SameBaseUnits(o.unit) {
 		q = q.WithStandardUnits()
 		o = o.WithStandardUnits()
 	}
@@ -239,14 +275,26 @@ func (q Quantity) Compare(o Quantity) int {
 // but inches multiplied by yards yields a result in square meters since the
 // differing length units must be normalized to avoid producing the nonsense
 // unit "inch-yards".
+//
+// Logarithmic quantities cannot be multiplied, so this method will panic if
+// either quantity has a logarithmic unit. Neither can absolute temperatures
+// in a unit whose zero is not absolute zero, such as degrees Celsius, since
+// the result would depend on the choice of unit.
 func (q Quantity) Multiply(o Quantity) Quantity {
+	if q.unit.Logarithmic() || o.unit.Logarithmic() {
+		panic("Attempt to Multiply logarithmic quantities")
+	}
+	if q.unit.OffsetTemperature() || o.unit.OffsetTemperature() {
+		panic("Attempt to Multiply absolute temperatures")
+	}
+
 	if !q.unit.SameBaseUnits(o.unit) {
 		q = q.WithStandardUnits()
 		o = o.WithStandardUnits()
 	}
 
 	nu := q.unit.Multiply(o.unit)
-	nv := (&big.Float{}).Mul(q.value, o.value)
+	nv := q.value.Mul(o.value)
 
 	return Quantity{
 		unit:  nu,
@@ -257,15 +305,23 @@ func (q Quantity) Multiply(o Quantity) Quantity {
 // Divide computes the quotient of the receiver by the given quantity,
 // dividing both the value and the units to produce a new quantity.
 //
-// The same normalization of unit applies as for the Multiply method.
+// The same normalization of unit and the same restrictions apply as for the
+// Multiply method.
 func (q Quantity) Divide(o Quantity) Quantity {
+	if q.unit.Logarithmic() || o.unit.Logarithmic() {
+		panic("Attempt to Divide logarithmic quantities")
+	}
+	if q.unit.OffsetTemperature() || o.unit.OffsetTemperature() {
+		panic("Attempt to Divide absolute temperatures")
+	}
+
 	if !q.unit.SameBaseUnits(o.unit) {
 		q = q.WithStandardUnits()
 		o = o.WithStandardUnits()
 	}
 
 	nu := q.unit.Multiply(o.unit.Reciprocal())
-	nv := (&big.Float{}).Quo(q.value, o.value)
+	nv := q.value.Quo(o.value)
 
 	return Quantity{
 		unit:  nu,
@@ -280,17 +336,36 @@ func (q Quantity) Divide(o Quantity) Quantity {
 //
 // If the units are identical (same base units) then the result will have the
 // same units. Otherwise, the result will be in the standard units.
+//
+// Absolute temperatures in a unit whose zero is not absolute zero, such as
+// degrees Celsius, are an exception: the other quantity is taken to be a
+// temperature difference, which must be in a unit without an offset, such
+// as kelvins, and the result is in the absolute temperature's unit. For
+// example, 25 degC plus 10 K is 35 degC, as is 10 K plus 25 degC. Adding two
+// such absolute temperatures is meaningless, so will panic.
+//
+// Logarithmic quantities can be added only if at least one of them is a gain
+// in dB, and the result is in the unit of the other. For example, 10 dBm plus
+// 3 dB is 13 dBm, but adding 10 dBm to 10 dBm will panic.
 func (q Quantity) Add(o Quantity) Quantity {
+	if q.unit.Logarithmic() || o.unit.Logarithmic() {
+		return sumLevels(q, o, false)
+	}
+
 	if !q.CommensurableWith(o) {
 		panic("Attempt to Add non-commensurable quantities")
 	}
 
+	if q.unit.OffsetTemperature() || o.unit.OffsetTemperature() {
+		return sumTemperatures(q, o, false)
+	}
+
 	if !q.unit.SameBaseUnits(o.unit) {
 		q = q.WithStandardUnits()
 		o = o.WithStandardUnits()
 	}
 
-	nv := (&big.Float{}).Add(q.value, o.value)
+	nv := q.value.Add(o.value)
 
 	return Quantity{
 		unit:  q.unit,
@@ -305,17 +380,35 @@ func (q Quantity) Add(o Quantity) Quantity {
 //
 // If the units are identical (same base units) then the result will have the
 // same units. Otherwise, the result will be in the standard units.
+//
+// A temperature difference can be subtracted from an absolute temperature in
+// a unit whose zero is not absolute zero, such as degrees Celsius, giving a
+// result in the same unit, as for Add. The difference between two absolute
+// temperatures is a temperature difference in kelvins. For example, 30 degC
+// minus 10 K is 20 degC, but 45 degC minus 25 degC is 20 K.
+//
+// A gain in dB can be subtracted from a logarithmic quantity, giving a result
+// in the same unit, and the difference between two levels of the same
+// dimensionality is a gain. For example, 13 dBm minus 10 dBm is 3 dB.
 func (q Quantity) Subtract(o Quantity) Quantity {
+	if q.unit.Logarithmic() || o.unit.Logarithmic() {
+		return sumLevels(q, o, true)
+	}
+
 	if !q.CommensurableWith(o) {
 		panic("Attempt to Subtract non-commensurable quantities")
 	}
 
+	if q.unit.OffsetTemperature() || o.unit.OffsetTemperature() {
+		return sumTemperatures(q, o, true)
+	}
+
 	if !q.unit.SameBaseUnits(o.unit) {
 		q = q.WithStandardUnits()
 		o = o.WithStandardUnits()
 	}
 
-	nv := (&big.Float{}).Sub(q.value, o.value)
+	nv := q.value.Sub(o.value)
 
 	return Quantity{
 		unit:  q.unit,
@@ -323,6 +416,62 @@ func (q Quantity) Subtract(o Quantity) Quantity {
 	}
 }
 
+// AsDifference returns the receiver unchanged unless it is an absolute
+// temperature in a unit whose zero is not absolute zero, such as degrees
+// Celsius, in which case it returns the temperature difference of the same
+// magnitude in kelvins. For example, 9 degF becomes 5 K.
+//
+// This is useful when a quantity that was written in such a unit is known
+// to be a difference, such as the tolerance of a temperature.
+func (q Quantity) AsDifference() Quantity {
+	if !q.unit.OffsetTemperature() {
+		return q
+	}
+	return Quantity{
+		unit:  unitByName["K"],
+		value: q.value.Quo(q.unit.base.Temperature.scaleNum),
+	}
+}
+
+// sumTemperatures is the implementation of Add and Subtract for commensurable
+// temperatures where at least one is an absolute temperature in a unit whose
+// zero is not absolute zero. See the documentation of those methods for the
+// rules that apply.
+func sumTemperatures(q, o Quantity, subtract bool) Quantity {
+	switch {
+	case o.unit.OffsetTemperature() && (subtract || q.unit.OffsetTemperature()):
+		if !subtract {
+			panic("Attempt to Add two absolute temperatures")
+		}
+		// Both are absolute temperatures, so we can find the difference in
+		// the receiver's unit and then scale it, without an offset, to
+		// kelvins.
+		nv := q.value.Sub(o.Convert(q.unit).value)
+		nv = nv.Quo(q.unit.base.Temperature.scaleNum)
+		return Quantity{
+			unit:  unitByName["K"],
+			value: nv,
+		}
+	case o.unit.OffsetTemperature():
+		// Addition is commutative, so we can swap the operands to put the
+		// absolute temperature first.
+		q, o = o, q
+	}
+
+	// The other quantity is a difference, so we scale it to the receiver's
+	// unit without applying the offset.
+	d := o.value.Quo(o.unit.base.Temperature.scaleNum)
+	d = d.Mul(q.unit.base.Temperature.scaleNum)
+	nv := q.value.Add(d)
+	if subtract {
+		nv = q.value.Sub(d)
+	}
+	return Quantity{
+		unit:  q.unit,
+		value: nv,
+	}
+}
+
 // FormatValue returns a string representation of the value expressed in the
 // given unit.
 //
@@ -342,7 +491,7 @@ func (q Quantity) FormatValue(format byte, prec int, unit *Unit) string {
 		q = q.Convert(unit)
 	}
 
-	return q.value.Text(format, prec)
+	return q.value.Big().Text(format, prec)
 }
 
 // String returns a compact, human-readable representation of the receiver.
@@ -360,7 +509,8 @@ func (q Quantity) String() string {
 }
 
 func (q Quantity) GoString() string {
-	if iv, acc := q.value.Int64(); acc == big.Exact {
+	value := q.value.Big()
+	if iv, acc := value.Int64(); acc == big.Exact {
 		if q.unit == unitByName[""] {
 			return fmt.Sprintf("units.MakeDimensionlessInt(%d)", iv)
 		}
@@ -368,7 +518,7 @@ func (q Quantity) GoString() string {
 		return fmt.Sprintf("units.MakeQuantityInt(%d, %#v)", iv, q.unit)
 	}
 
-	if fv, acc := q.value.Float64(); acc == big.Exact {
+	if fv, acc := value.Float64(); acc == big.Exact {
 		if q.unit == unitByName[""] {
 			return fmt.Sprintf("units.MakeDimensionlessFloat(%f)", fv)
 		}
@@ -377,8 +527,8 @@ func (q Quantity) GoString() string {
 	}
 
 	if q.unit == unitByName[""] {
-		return fmt.Sprintf("units.MakeDimensionless((&big.Float{}).Parse(%q))", q.value.String())
+		return fmt.Sprintf("units.MakeDimensionless((&big.Float{}).Parse(%q))", value.String())
 	}
 
-	return fmt.Sprintf("units.MakeQuantity((&big.Float{}).Parse(%q), %#v)", q.value.String(), q.unit)
+	return fmt.Sprintf("units.MakeQuantity((&big.Float{}).Parse(%q), %#v)", value.String(), q.unit)
 }
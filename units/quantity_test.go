@@ -48,23 +48,23 @@ func TestQuantityConvert(t *testing.T) {
 			"36 in",
 		},
 		{
-			q("1", &Unit{Dimensionality{Length: 2}, baseUnits{Length: meter}, 0}),
-			&Unit{Dimensionality{Length: 2}, baseUnits{Length: centimeter}, 0},
+			q("1", &Unit{Dimensionality{Length: 2}, baseUnits{Length: meter}, 0, ""}),
+			&Unit{Dimensionality{Length: 2}, baseUnits{Length: centimeter}, 0, ""},
 			"10000 cm²",
 		},
 		{
-			q("1", &Unit{Dimensionality{Length: 3}, baseUnits{Length: meter}, 0}),
-			&Unit{Dimensionality{Length: 3}, baseUnits{Length: centimeter}, 0},
+			q("1", &Unit{Dimensionality{Length: 3}, baseUnits{Length: meter}, 0, ""}),
+			&Unit{Dimensionality{Length: 3}, baseUnits{Length: centimeter}, 0, ""},
 			"1000000 cm³",
 		},
 		{
-			q("1", &Unit{Dimensionality{Length: -2}, baseUnits{Length: meter}, 0}),
-			&Unit{Dimensionality{Length: -2}, baseUnits{Length: centimeter}, 0},
+			q("1", &Unit{Dimensionality{Length: -2}, baseUnits{Length: meter}, 0, ""}),
+			&Unit{Dimensionality{Length: -2}, baseUnits{Length: centimeter}, 0, ""},
 			"0.0001 cm⁻²",
 		},
 		{
-			q("1", &Unit{Dimensionality{Length: -3}, baseUnits{Length: meter}, 0}),
-			&Unit{Dimensionality{Length: -3}, baseUnits{Length: centimeter}, 0},
+			q("1", &Unit{Dimensionality{Length: -3}, baseUnits{Length: meter}, 0, ""}),
+			&Unit{Dimensionality{Length: -3}, baseUnits{Length: centimeter}, 0, ""},
 			"1e-06 cm⁻³",
 		},
 		{
@@ -87,6 +87,67 @@ func TestQuantityConvert(t *testing.T) {
 			unitByName["V"],
 			"1000 V",
 		},
+		{
+			q("100", unitByName["nF"]),
+			unitByName["uF"],
+			"0.1 uF",
+		},
+		{
+			q("22", unitByName["pF"]),
+			unitByName["F"],
+			"2.2e-11 F",
+		},
+		{
+			q("1", unitByName["ns"]),
+			unitByName["ps"],
+			"1000 ps",
+		},
+		{
+			q("250", unitByName["uA"]),
+			unitByName["mA"],
+			"0.25 mA",
+		},
+		{
+			q("25", unitByName["degC"]),
+			unitByName["K"],
+			"298.15 K",
+		},
+		{
+			q("212", unitByName["degF"]),
+			unitByName["degC"],
+			"100 degC",
+		},
+		{
+			q("-40", unitByName["degC"]),
+			unitByName["degF"],
+			"-40 degF",
+		},
+		{
+			q("300", unitByName["mK"]),
+			unitByName["K"],
+			"0.3 K",
+		},
+		{
+			// Temperatures in compound units are differences, so no offset
+			q("9", &Unit{Dimensionality{Temperature: 1, Time: -1}, baseUnits{Temperature: fahrenheit, Time: second}, 0, ""}),
+			&Unit{Dimensionality{Temperature: 1, Time: -1}, baseUnits{Temperature: celsius, Time: second}, 0, ""},
+			"5 degC s⁻¹",
+		},
+		{
+			q("1", unitByName["GHz"]),
+			unitByName["MHz"],
+			"1000 MHz",
+		},
+		{
+			q("50", unitByName["mohm"]),
+			unitByName["ohm"],
+			"0.05 ohm",
+		},
+		{
+			q("1", unitByName["um"]),
+			unitByName["mil"],
+			"0.03937007874 mil",
+		},
 	}
 
 	for _, test := range tests {
@@ -166,6 +227,7 @@ func TestQuantityWithStandardUnits(t *testing.T) {
 				Dimensionality{Length: 1, Time: -1},
 				baseUnits{Length: inch, Time: microsecond},
 				0,
+				"",
 			}),
 			"0.0254 m s⁻¹",
 		},
@@ -178,6 +240,7 @@ func TestQuantityWithStandardUnits(t *testing.T) {
 				Dimensionality{Mass: 1, Length: 2, Time: -3, ElectricCurrent: -1},
 				baseUnits{Mass: kilogram, Length: inch, Time: second, ElectricCurrent: ampere},
 				0,
+				"",
 			}),
 			"0.64516 V",
 		},
@@ -235,6 +298,12 @@ func TestQuantityMultiply(t *testing.T) {
 			MakeQuantity(bfp("2"), unitByName["in"]),
 			"0.001016 m²",
 		},
+		{
+			// Junction temperature rise from a thermal resistance
+			MakeQuantity(bfp("40"), unitByName["K"]).Divide(MakeQuantity(bfp("1"), unitByName["W"])),
+			MakeQuantity(bfp("0.5"), unitByName["W"]),
+			"20 K",
+		},
 		{
 			MakeQuantity(bfp("2"), unitByName["s"]).Multiply(MakeQuantity(bfp("2"), unitByName["s"])),
 			MakeQuantity(bfp("2"), unitByName["s"]),
@@ -433,6 +502,26 @@ func TestQuantityAdd(t *testing.T) {
 			MakeQuantity(bfp("2"), unitByName["ft"]),
 			"2.6096 m",
 		},
+		{
+			MakeQuantity(bfp("25"), unitByName["degC"]),
+			MakeQuantity(bfp("40"), unitByName["K"]),
+			"65 degC",
+		},
+		{
+			MakeQuantity(bfp("10"), unitByName["K"]),
+			MakeQuantity(bfp("25"), unitByName["degC"]),
+			"35 degC",
+		},
+		{
+			MakeQuantity(bfp("77"), unitByName["degF"]),
+			MakeQuantity(bfp("10"), unitByName["K"]),
+			"95 degF",
+		},
+		{
+			MakeQuantity(bfp("300"), unitByName["K"]),
+			MakeQuantity(bfp("10"), unitByName["K"]),
+			"310 K",
+		},
 		{
 			MakeQuantity(bfp("2"), unitByName["s"]).Multiply(MakeQuantity(bfp("2"), unitByName["s"])),
 			MakeQuantity(bfp("2"), unitByName["s"]).Multiply(MakeQuantity(bfp("2"), unitByName["s"])),
@@ -507,6 +596,31 @@ func TestQuantitySubtract(t *testing.T) {
 			MakeDimensionless(bfp("1")),
 			"1",
 		},
+		{
+			MakeQuantity(bfp("30"), unitByName["degC"]),
+			MakeQuantity(bfp("10"), unitByName["K"]),
+			"20 degC",
+		},
+		{
+			MakeQuantity(bfp("45"), unitByName["degC"]),
+			MakeQuantity(bfp("25"), unitByName["degC"]),
+			"20 K",
+		},
+		{
+			MakeQuantity(bfp("212"), unitByName["degF"]),
+			MakeQuantity(bfp("32"), unitByName["degF"]),
+			"100 K",
+		},
+		{
+			MakeQuantity(bfp("25"), unitByName["degC"]),
+			MakeQuantity(bfp("50"), unitByName["degF"]),
+			"15 K",
+		},
+		{
+			MakeQuantity(bfp("373.15"), unitByName["K"]),
+			MakeQuantity(bfp("25"), unitByName["degC"]),
+			"75 K",
+		},
 		{
 			MakeQuantity(bfp("2"), unitByName["m"]),
 			MakeQuantity(bfp("1"), unitByName["m"]),
@@ -570,6 +684,51 @@ func TestQuantitySubtract(t *testing.T) {
 	}
 }
 
+// TestQuantityJunctionTemperature tests the typical calculations using a
+// package's junction-to-ambient thermal resistance, θJA.
+func TestQuantityJunctionTemperature(t *testing.T) {
+	thetaJA := q("40", unitByName["K"]).Divide(q("1", unitByName["W"]))
+	ambient := q("25", unitByName["degC"])
+
+	// The junction temperature when dissipating 2 W.
+	tj := ambient.Add(q("2", unitByName["W"]).Multiply(thetaJA))
+	if got, want := tj.String(), "105 degC"; got != want {
+		t.Errorf("wrong junction temperature\ngot:  %s\nwant: %s", got, want)
+	}
+
+	// The power that can be dissipated with a maximum junction temperature
+	// of 125 degC.
+	pmax := q("125", unitByName["degC"]).Subtract(ambient).Divide(thetaJA)
+	if want := q("2.5", unitByName["W"]); !pmax.Equal(want) {
+		t.Errorf("wrong maximum power\ngot:  %s\nwant: %s", pmax, want)
+	}
+}
+
+func TestQuantityTemperatureInvalid(t *testing.T) {
+	tests := map[string]func(){
+		"degC + degF": func() {
+			q("25", unitByName["degC"]).Add(q("18", unitByName["degF"]))
+		},
+		"2 * degC": func() {
+			q("2", dimless).Multiply(q("25", unitByName["degC"]))
+		},
+		"degF / W": func() {
+			q("77", unitByName["degF"]).Divide(q("1", unitByName["W"]))
+		},
+	}
+
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("did not panic")
+				}
+			}()
+			f()
+		})
+	}
+}
+
 func TestQuantityFormatValue(t *testing.T) {
 	tests := []struct {
 		Input  Quantity
@@ -649,6 +808,7 @@ func TestQuantityString(t *testing.T) {
 				Dimensionality{Length: 1, Time: -2},
 				baseUnits{Length: meter, Time: second},
 				0,
+				"",
 			}),
 			"2.89 m s⁻²",
 		},
@@ -664,6 +824,90 @@ func TestQuantityString(t *testing.T) {
 	}
 }
 
+// BenchmarkQuantityAdd measures the sum of two resistances, where the
+// exact values can stay on the float64 fast path and the inexact values
+// must fall back to big floats.
+func BenchmarkQuantityAdd(b *testing.B) {
+	benchQuantityPairs(b, Quantity.Add, unitByName["ohm"], unitByName["ohm"])
+}
+
+// BenchmarkQuantityMultiply measures the product of a current and a
+// resistance.
+func BenchmarkQuantityMultiply(b *testing.B) {
+	benchQuantityPairs(b, Quantity.Multiply, unitByName["A"], unitByName["ohm"])
+}
+
+// BenchmarkQuantityDivide measures the quotient of a voltage and a
+// resistance.
+func BenchmarkQuantityDivide(b *testing.B) {
+	benchQuantityPairs(b, Quantity.Divide, unitByName["V"], unitByName["ohm"])
+}
+
+// BenchmarkQuantityConvert measures conversions between units whose scales
+// are exact float64 values and those whose scales are not.
+func BenchmarkQuantityConvert(b *testing.B) {
+	tests := []struct {
+		Name string
+		Q    Quantity
+		U    *Unit
+	}{
+		{"mm to m", q("1500", unitByName["mm"]), unitByName["m"]},
+		{"kohm to ohm", q("47", unitByName["kohm"]), unitByName["ohm"]},
+		{"in to mm", q("1", unitByName["in"]), unitByName["mm"]},
+		{"degC to K", q("25", unitByName["degC"]), unitByName["K"]},
+	}
+
+	for _, test := range tests {
+		b.Run(test.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				test.Q.Convert(test.U)
+			}
+		})
+	}
+}
+
+// BenchmarkQuantityDividerSweep measures the output voltage of a divider
+// for each pair of E12 resistor values, as might be computed when choosing
+// feedback resistors for a regulator.
+func BenchmarkQuantityDividerSweep(b *testing.B) {
+	e12 := []string{"1", "1.2", "1.5", "1.8", "2.2", "2.7", "3.3", "3.9", "4.7", "5.6", "6.8", "8.2"}
+	var rs []Quantity
+	for _, v := range e12 {
+		rs = append(rs, q(v, unitByName["kohm"]))
+	}
+	vin := q("12", unitByName["V"])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, r1 := range rs {
+			for _, r2 := range rs {
+				vin.Multiply(r2).Divide(r1.Add(r2))
+			}
+		}
+	}
+}
+
+// benchQuantityPairs runs the given binary operation on quantities in the
+// given units, once with values that are exact float64 values and once with
+// values that are not.
+func benchQuantityPairs(b *testing.B, op func(a, b Quantity) Quantity, ua, ub *Unit) {
+	tests := []struct {
+		Name string
+		A, B Quantity
+	}{
+		{"exact", q("100", ua), q("2.5", ub)},
+		{"inexact", q("4.7", ua), q("3.3", ub)},
+	}
+
+	for _, test := range tests {
+		b.Run(test.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				op(test.A, test.B)
+			}
+		})
+	}
+}
+
 func q(v string, u *Unit) Quantity {
 	f, _, err := (&big.Float{}).Parse(v, 10)
 	if err != nil {
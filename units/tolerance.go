@@ -0,0 +1,203 @@
+package units
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TolerancedQuantity is a quantity whose value is known only to lie within a
+// range, such as the value of a resistor with a 1% tolerance. It tracks the
+// minimum, nominal and maximum values, all expressed in the same unit.
+//
+// Arithmetic on toleranced quantities uses interval arithmetic, so the
+// minimum and maximum of each result are the worst cases given the ranges of
+// the operands. Each operand is assumed to vary independently, so an
+// expression that uses the same toleranced quantity more than once, such as
+// the ratio R2/(R1+R2) of a voltage divider, will produce a range that is
+// wider than the true worst case.
+type TolerancedQuantity struct {
+	min, nom, max Quantity
+}
+
+// MakeTolerancedQuantity initializes a TolerancedQuantity with the given
+// minimum, nominal and maximum values. The minimum and maximum are converted
+// to the unit of the nominal value.
+//
+// Will panic if the three quantities are not commensurable, or if the
+// nominal value does not lie between the minimum and the maximum.
+func MakeTolerancedQuantity(min, nom, max Quantity) TolerancedQuantity {
+	if !nom.CommensurableWith(min) || !nom.CommensurableWith(max) {
+		panic("attempt to make toleranced quantity from incommensurable quantities")
+	}
+	min = min.Convert(nom.unit)
+	max = max.Convert(nom.unit)
+	if min.Compare(nom) > 0 || max.Compare(nom) < 0 {
+		panic("attempt to make toleranced quantity with nominal value out of range")
+	}
+
+	return TolerancedQuantity{
+		min: min,
+		nom: nom,
+		max: max,
+	}
+}
+
+// MakeExact returns a TolerancedQuantity with no tolerance, whose minimum,
+// nominal and maximum values are all the given quantity.
+func MakeExact(q Quantity) TolerancedQuantity {
+	return TolerancedQuantity{
+		min: q,
+		nom: q,
+		max: q,
+	}
+}
+
+// WithTolerance returns a TolerancedQuantity whose nominal value is the
+// receiver and whose range extends below and above it by the given
+// fractions of the receiver. For example, a 100 nF capacitor with a
+// tolerance of +80%/-20% is:
+//
+//     nom.WithTolerance(big.NewFloat(0.2), big.NewFloat(0.8))
+func (q Quantity) WithTolerance(minus, plus *big.Float) TolerancedQuantity {
+	lo := (&big.Float{}).Sub(&one, minus)
+	lo.Mul(lo, q.value.Big())
+	hi := (&big.Float{}).Add(&one, plus)
+	hi.Mul(hi, q.value.Big())
+	if q.value.Sign() < 0 {
+		lo, hi = hi, lo
+	}
+
+	return TolerancedQuantity{
+		min: MakeQuantity(lo, q.unit),
+		nom: q,
+		max: MakeQuantity(hi, q.unit),
+	}
+}
+
+// Min returns the minimum value of the receiver.
+func (q TolerancedQuantity) Min() Quantity {
+	return q.min
+}
+
+// Nom returns the nominal value of the receiver.
+func (q TolerancedQuantity) Nom() Quantity {
+	return q.nom
+}
+
+// Max returns the maximum value of the receiver.
+func (q TolerancedQuantity) Max() Quantity {
+	return q.max
+}
+
+// Unit returns the unit of the receiving quantity.
+func (q TolerancedQuantity) Unit() *Unit {
+	return q.nom.unit
+}
+
+// Exact returns true if the receiver has no tolerance, meaning that its
+// minimum, nominal and maximum values are all equal.
+func (q TolerancedQuantity) Exact() bool {
+	return q.min.Equal(q.nom) && q.max.Equal(q.nom)
+}
+
+// Convert returns a new TolerancedQuantity that is equivalent to the receiver
+// but is expressed in the given unit.
+//
+// Will panic if the receiver's unit is not commensurable with the given unit.
+func (q TolerancedQuantity) Convert(new *Unit) TolerancedQuantity {
+	return TolerancedQuantity{
+		min: q.min.Convert(new),
+		nom: q.nom.Convert(new),
+		max: q.max.Convert(new),
+	}
+}
+
+// Equal returns true if and only if the receiver's minimum, nominal and
+// maximum values are each equal to those of the given quantity.
+func (q TolerancedQuantity) Equal(o TolerancedQuantity) bool {
+	return q.min.Equal(o.min) && q.nom.Equal(o.nom) && q.max.Equal(o.max)
+}
+
+// Add computes the sum of the receiver and the given quantity, which must
+// have commensurable units.
+//
+// The units of the result are as for Quantity.Add.
+func (q TolerancedQuantity) Add(o TolerancedQuantity) TolerancedQuantity {
+	return TolerancedQuantity{
+		min: q.min.Add(o.min),
+		nom: q.nom.Add(o.nom),
+		max: q.max.Add(o.max),
+	}
+}
+
+// Subtract computes the difference between the receiver and the given
+// quantity, which must have commensurable units.
+//
+// The minimum of the result is the receiver's minimum less the other
+// quantity's maximum, and vice-versa.
+func (q TolerancedQuantity) Subtract(o TolerancedQuantity) TolerancedQuantity {
+	return TolerancedQuantity{
+		min: q.min.Subtract(o.max),
+		nom: q.nom.Subtract(o.nom),
+		max: q.max.Subtract(o.min),
+	}
+}
+
+// Multiply computes the product of the receiver and the given quantity.
+//
+// The units of the result are as for Quantity.Multiply.
+func (q TolerancedQuantity) Multiply(o TolerancedQuantity) TolerancedQuantity {
+	return TolerancedQuantity{
+		min: q.min.Multiply(o.min),
+		nom: q.nom.Multiply(o.nom),
+		max: q.max.Multiply(o.max),
+	}.extremes(q, o, Quantity.Multiply)
+}
+
+// Divide computes the quotient of the receiver by the given quantity.
+//
+// Will panic if the range of the given quantity includes zero, since the
+// range of the result would then be unbounded.
+func (q TolerancedQuantity) Divide(o TolerancedQuantity) TolerancedQuantity {
+	if o.min.value.Sign() <= 0 && o.max.value.Sign() >= 0 {
+		panic("Attempt to Divide by a toleranced quantity whose range includes zero")
+	}
+
+	return TolerancedQuantity{
+		min: q.min.Divide(o.min),
+		nom: q.nom.Divide(o.nom),
+		max: q.max.Divide(o.max),
+	}.extremes(q, o, Quantity.Divide)
+}
+
+// extremes sets the minimum and maximum of the receiver to the least and
+// greatest results of the given operation over each combination of the
+// bounds of the two given operands.
+//
+// This is necessary for multiplication and division, where negative bounds
+// can cause the combination of the two minimums to produce the greatest
+// result rather than the least.
+func (q TolerancedQuantity) extremes(a, b TolerancedQuantity, op func(Quantity, Quantity) Quantity) TolerancedQuantity {
+	for _, ab := range []Quantity{a.min, a.max} {
+		for _, bb := range []Quantity{b.min, b.max} {
+			r := op(ab, bb)
+			if r.Compare(q.min) < 0 {
+				q.min = r
+			}
+			if r.Compare(q.max) > 0 {
+				q.max = r
+			}
+		}
+	}
+	return q
+}
+
+// String returns a compact, human-readable representation of the receiver.
+//
+// It is primarily intended for debugging and is thus not optimized.
+func (q TolerancedQuantity) String() string {
+	if q.Exact() {
+		return q.nom.String()
+	}
+	return fmt.Sprintf("%s (%s to %s)", q.nom, q.min.value.String(), q.max.value.String())
+}
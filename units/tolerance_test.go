@@ -0,0 +1,76 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestTolerancedQuantityArithmetic(t *testing.T) {
+	r1 := q("10", unitByName["kohm"]).WithTolerance(bfp("0.01"), bfp("0.01"))
+	r2 := q("4.7", unitByName["kohm"]).WithTolerance(bfp("0.05"), bfp("0.05"))
+	c := q("100", unitByName["nF"]).WithTolerance(bfp("0.2"), bfp("0.8"))
+	v := MakeTolerancedQuantity(q("4.75", unitByName["V"]), q("5", unitByName["V"]), q("5.25", unitByName["V"]))
+
+	tests := []struct {
+		Name string
+		Got  TolerancedQuantity
+		Want string
+	}{
+		{"tolerance", r1, "10 kohm (9.9 to 10.1)"},
+		{"asymmetric", c, "100 nF (80 to 180)"},
+		{"negative", q("-2", unitByName["V"]).WithTolerance(bfp("0.1"), bfp("0.1")), "-2 V (-2.2 to -1.8)"},
+		{"exact", MakeExact(q("3", unitByName["V"])), "3 V"},
+		{"add", r1.Add(r2), "14.7 kohm (14.365 to 15.035)"},
+		{"subtract", r1.Subtract(r2), "5.3 kohm (4.965 to 5.635)"},
+		{"multiply", v.Multiply(MakeExact(q("2", dimless))), "10 V (9.5 to 10.5)"},
+		{"divide", v.Divide(MakeExact(q("1", unitByName["ohm"]))), "5 A (4.75 to 5.25)"},
+		{"convert", r1.Convert(unitByName["ohm"]), "10000 ohm (9900 to 10100)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if got := test.Got.String(); got != test.Want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestTolerancedQuantityRC(t *testing.T) {
+	// Worst-case time constant of a timing network
+	r := q("10", unitByName["kohm"]).WithTolerance(bfp("0.01"), bfp("0.01"))
+	c := q("100", unitByName["nF"]).WithTolerance(bfp("0.1"), bfp("0.1"))
+	tau := r.Multiply(c).Convert(unitByName["ms"])
+
+	for _, test := range []struct {
+		Got  Quantity
+		Want string
+	}{
+		{tau.Min(), "0.891 ms"},
+		{tau.Nom(), "1 ms"},
+		{tau.Max(), "1.111 ms"},
+	} {
+		if got := test.Got.String(); got != test.Want {
+			t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.Want)
+		}
+	}
+}
+
+func TestTolerancedQuantityMultiplyNegative(t *testing.T) {
+	a := MakeTolerancedQuantity(q("-2", dimless), q("1", dimless), q("3", dimless))
+	b := MakeTolerancedQuantity(q("-5", dimless), q("1", dimless), q("4", dimless))
+	got := a.Multiply(b)
+	if want := "1 (-15 to 12)"; got.String() != want {
+		t.Errorf("wrong result\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestTolerancedQuantityDivideByZero(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("did not panic")
+		}
+	}()
+	a := MakeExact(q("1", dimless))
+	b := MakeTolerancedQuantity(q("-1", dimless), q("1", dimless), q("2", dimless))
+	a.Divide(b)
+}
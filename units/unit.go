@@ -20,11 +20,21 @@ type Unit struct {
 	// Scaling is only used for derived units. Units of base dimensions are
 	// just represented directly.
 	scale int64
+
+	// name is set only for units created by DefineUnit, which are not
+	// registered in the tables of named units and so must carry their
+	// names with them.
+	name string
 }
 
 // ByName returns the unit with the given name, or nil if the name is not
 // recognized as a unit.
 //
+// Any of the SI prefixes p, n, u (or µ), m, k, M and G may be combined with
+// the symbol of any SI unit, such as "nF" or "mohm". Degrees Celsius and
+// Fahrenheit are named "degC" and "degF", but "°C" and "°F" are also
+// accepted.
+//
 // This function only works with units that have specifically been named. It
 // cannot be used to create unnamed derived units, such as "meters squared";
 // these must be constructed, such as:
@@ -51,60 +61,68 @@ func AllNames() []string {
 	return ret
 }
 
-var dimless = &Unit{Dimensionality{}, baseUnits{}, 0}
+var dimless = &Unit{Dimensionality{}, baseUnits{}, 0, ""}
 
 var unitByName map[string]*Unit = map[string]*Unit{
 	// Dimensionless
 	"": dimless,
 
 	// Mass Units
-	"kg": &Unit{Dimensionality{Mass: 1}, baseUnits{Mass: kilogram}, 0},
-	"g":  &Unit{Dimensionality{Mass: 1}, baseUnits{Mass: gram}, 0},
-	"lb": &Unit{Dimensionality{Mass: 1}, baseUnits{Mass: pound}, 0},
-	"st": &Unit{Dimensionality{Mass: 1}, baseUnits{Mass: stone}, 0},
+	"kg": &Unit{Dimensionality{Mass: 1}, baseUnits{Mass: kilogram}, 0, ""},
+	"g":  &Unit{Dimensionality{Mass: 1}, baseUnits{Mass: gram}, 0, ""},
+	"lb": &Unit{Dimensionality{Mass: 1}, baseUnits{Mass: pound}, 0, ""},
+	"st": &Unit{Dimensionality{Mass: 1}, baseUnits{Mass: stone}, 0, ""},
 
 	// Length Units
-	"m":   &Unit{Dimensionality{Length: 1}, baseUnits{Length: meter}, 0},
-	"mm":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: millimeter}, 0},
-	"cm":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: centimeter}, 0},
-	"km":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: kilometer}, 0},
-	"mil": &Unit{Dimensionality{Length: 1}, baseUnits{Length: mil}, 0},
-	"in":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: inch}, 0},
-	"ft":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: foot}, 0},
-	"yd":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: yard}, 0},
+	"m":   &Unit{Dimensionality{Length: 1}, baseUnits{Length: meter}, 0, ""},
+	"mm":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: millimeter}, 0, ""},
+	"cm":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: centimeter}, 0, ""},
+	"km":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: kilometer}, 0, ""},
+	"mil": &Unit{Dimensionality{Length: 1}, baseUnits{Length: mil}, 0, ""},
+	"in":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: inch}, 0, ""},
+	"ft":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: foot}, 0, ""},
+	"yd":  &Unit{Dimensionality{Length: 1}, baseUnits{Length: yard}, 0, ""},
 
 	// Angle Units
-	"deg":  &Unit{Dimensionality{Angle: 1}, baseUnits{Angle: degree}, 0},
-	"rad":  &Unit{Dimensionality{Angle: 1}, baseUnits{Angle: radian}, 0},
-	"turn": &Unit{Dimensionality{Angle: 1}, baseUnits{Angle: turn}, 0},
+	"deg":  &Unit{Dimensionality{Angle: 1}, baseUnits{Angle: degree}, 0, ""},
+	"rad":  &Unit{Dimensionality{Angle: 1}, baseUnits{Angle: radian}, 0, ""},
+	"turn": &Unit{Dimensionality{Angle: 1}, baseUnits{Angle: turn}, 0, ""},
 
 	// Time Units
-	"s":  &Unit{Dimensionality{Time: 1}, baseUnits{Time: second}, 0}, // There is no secs in physics.
-	"ms": &Unit{Dimensionality{Time: 1}, baseUnits{Time: millisecond}, 0},
-	"us": &Unit{Dimensionality{Time: 1}, baseUnits{Time: microsecond}, 0},
+	"s":  &Unit{Dimensionality{Time: 1}, baseUnits{Time: second}, 0, ""}, // There is no secs in physics.
+	"ms": &Unit{Dimensionality{Time: 1}, baseUnits{Time: millisecond}, 0, ""},
+	"us": &Unit{Dimensionality{Time: 1}, baseUnits{Time: microsecond}, 0, ""},
 
 	// Electric Current Units
-	"A":  &Unit{Dimensionality{ElectricCurrent: 1}, baseUnits{ElectricCurrent: ampere}, 0},
-	"mA": &Unit{Dimensionality{ElectricCurrent: 1}, baseUnits{ElectricCurrent: milliampere}, 0},
+	"A":  &Unit{Dimensionality{ElectricCurrent: 1}, baseUnits{ElectricCurrent: ampere}, 0, ""},
+	"mA": &Unit{Dimensionality{ElectricCurrent: 1}, baseUnits{ElectricCurrent: milliampere}, 0, ""},
 
 	// Luminous Intensity Units
-	"cd": &Unit{Dimensionality{LuminousIntensity: 1}, baseUnits{LuminousIntensity: candela}, 0},
+	"cd": &Unit{Dimensionality{LuminousIntensity: 1}, baseUnits{LuminousIntensity: candela}, 0, ""},
+
+	// Temperature Units
+	"K":    &Unit{Dimensionality{Temperature: 1}, baseUnits{Temperature: kelvin}, 0, ""},
+	"degC": &Unit{Dimensionality{Temperature: 1}, baseUnits{Temperature: celsius}, 0, ""},
+	"degF": &Unit{Dimensionality{Temperature: 1}, baseUnits{Temperature: fahrenheit}, 0, ""},
 
 	// Electic Resistance Units
 	"ohm": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3, ElectricCurrent: -2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		0,
+		"",
 	},
 	"kohm": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3, ElectricCurrent: -2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		1000,
+		"",
 	},
 	"Mohm": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3, ElectricCurrent: -2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		1000000,
+		"",
 	},
 
 	// Electric Voltage Units
@@ -112,16 +130,19 @@ var unitByName map[string]*Unit = map[string]*Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3, ElectricCurrent: -1},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		0,
+		"",
 	},
 	"mV": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3, ElectricCurrent: -1},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		-1000,
+		"",
 	},
 	"kV": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3, ElectricCurrent: -1},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		1000,
+		"",
 	},
 
 	// Frequency Units
@@ -129,16 +150,19 @@ var unitByName map[string]*Unit = map[string]*Unit{
 		Dimensionality{Time: -1},
 		baseUnits{Time: second},
 		0,
+		"",
 	},
 	"kHz": &Unit{
 		Dimensionality{Time: -1},
 		baseUnits{Time: second},
 		1000,
+		"",
 	},
 	"MHz": &Unit{
 		Dimensionality{Time: -1},
 		baseUnits{Time: second},
 		1000000,
+		"",
 	},
 
 	// Force Units
@@ -146,6 +170,7 @@ var unitByName map[string]*Unit = map[string]*Unit{
 		Dimensionality{Mass: 1, Length: 1, Time: -2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second},
 		0,
+		"",
 	},
 
 	// Power Units
@@ -153,26 +178,31 @@ var unitByName map[string]*Unit = map[string]*Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3},
 		baseUnits{Mass: kilogram, Length: meter, Time: second},
 		0,
+		"",
 	},
 	"mW": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3},
 		baseUnits{Mass: kilogram, Length: meter, Time: second},
 		-1000,
+		"",
 	},
 	"kW": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3},
 		baseUnits{Mass: kilogram, Length: meter, Time: second},
 		1000,
+		"",
 	},
 	"MW": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3},
 		baseUnits{Mass: kilogram, Length: meter, Time: second},
 		1000000,
+		"",
 	},
 	"GW": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -3},
 		baseUnits{Mass: kilogram, Length: meter, Time: second},
 		1000000000,
+		"",
 	},
 
 	// Electrical Capacitance Units
@@ -180,16 +210,19 @@ var unitByName map[string]*Unit = map[string]*Unit{
 		Dimensionality{Mass: -1, Length: -2, Time: 4, ElectricCurrent: 2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		0,
+		"",
 	},
 	"mF": &Unit{
 		Dimensionality{Mass: -1, Length: -2, Time: 4, ElectricCurrent: 2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		-1000,
+		"",
 	},
 	"uF": &Unit{
 		Dimensionality{Mass: -1, Length: -2, Time: 4, ElectricCurrent: 2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		-1000000,
+		"",
 	},
 
 	// Electric Charge Units
@@ -197,6 +230,7 @@ var unitByName map[string]*Unit = map[string]*Unit{
 		Dimensionality{Time: 1, ElectricCurrent: 1},
 		baseUnits{Time: second, ElectricCurrent: ampere},
 		0,
+		"",
 	},
 
 	// Electrical Inductance Units
@@ -204,11 +238,39 @@ var unitByName map[string]*Unit = map[string]*Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -2, ElectricCurrent: -2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		0,
+		"",
 	},
 	"uH": &Unit{
 		Dimensionality{Mass: 1, Length: 2, Time: -2, ElectricCurrent: -2},
 		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere},
 		-1000000,
+		"",
+	},
+
+	// Logarithmic Units
+	"dB": &Unit{
+		Dimensionality{},
+		baseUnits{Level: decibel},
+		0,
+		"",
+	},
+	"dBW": &Unit{
+		Dimensionality{Mass: 1, Length: 2, Time: -3},
+		baseUnits{Mass: kilogram, Length: meter, Time: second, Level: decibelWatt},
+		0,
+		"",
+	},
+	"dBm": &Unit{
+		Dimensionality{Mass: 1, Length: 2, Time: -3},
+		baseUnits{Mass: kilogram, Length: meter, Time: second, Level: decibelMilliwatt},
+		0,
+		"",
+	},
+	"dBV": &Unit{
+		Dimensionality{Mass: 1, Length: 2, Time: -3, ElectricCurrent: -1},
+		baseUnits{Mass: kilogram, Length: meter, Time: second, ElectricCurrent: ampere, Level: decibelVolt},
+		0,
+		"",
 	},
 
 	// Illuminance units
@@ -216,19 +278,34 @@ var unitByName map[string]*Unit = map[string]*Unit{
 		Dimensionality{Length: -2, LuminousIntensity: 1},
 		baseUnits{Length: meter, LuminousIntensity: candela},
 		0,
+		"",
 	},
 }
 
+// unitAliases are alternative names for some of the units in unitByName,
+// which are accepted by ByName but never produced in unit names.
+var unitAliases = map[string]string{
+	"°C": "degC",
+	"°F": "degF",
+}
+
 var units map[Unit]*Unit
 var unitName map[*Unit]string
 
 func init() {
+	addPrefixedUnits()
+
 	units = make(map[Unit]*Unit, len(unitByName))
 	unitName = make(map[*Unit]string, len(unitByName))
 	for name, unit := range unitByName {
 		units[*unit] = unit
 		unitName[unit] = name
 	}
+
+	addPrefixAliases()
+	for alias, name := range unitAliases {
+		unitByName[alias] = unitByName[name]
+	}
 }
 
 // Dimensionality returns the dimensionality of the receiver.
@@ -253,7 +330,8 @@ func (u *Unit) ToPower(power int) *Unit {
 // have the same dimensionality.
 //
 // For example, two length units are commensurable but a mass unit is not
-// commensurable with a length unit.
+// commensurable with a length unit. Logarithmic units are commensurable only
+// with other logarithmic units, so dBm is not commensurable with W.
 func (u *Unit) CommensurableWith(other *Unit) bool {
 	if u == nil && other == nil {
 		return true
@@ -262,14 +340,22 @@ func (u *Unit) CommensurableWith(other *Unit) bool {
 		return false
 	}
 
-	return u.dim == other.dim
+	return u.dim == other.dim && u.Logarithmic() == other.Logarithmic()
 }
 
 // ToStandardUnits returns a unit of equivalent dimensionality but that
 // uses the standard units for each base dimension. See the
 // Quantity.WithStandardUnits documentation for a table of the standard units.
+//
+// Logarithmic units are returned unchanged, since they already use the
+// standard units for each base dimension of their reference.
 func (u *Unit) ToStandardUnits() *Unit {
+	if u.Logarithmic() {
+		return u
+	}
+
 	nu := *u
+	nu.name = ""
 
 	if u.base.Mass != nil {
 		nu.base.Mass = kilogram
@@ -289,6 +375,9 @@ func (u *Unit) ToStandardUnits() *Unit {
 	if u.base.LuminousIntensity != nil {
 		nu.base.LuminousIntensity = candela
 	}
+	if u.base.Temperature != nil {
+		nu.base.Temperature = kelvin
+	}
 	nu.scale = 0
 
 	return (&nu).normalize()
@@ -303,10 +392,17 @@ func (u *Unit) ToStandardUnits() *Unit {
 // the result would be inch-centimeters, and that's a nonsense unit.
 // This method will panic if given differing base units.
 //
+// Logarithmic units cannot be multiplied at all, so this method will panic if
+// either unit is logarithmic.
+//
 // Use SameBaseUnits to determine if two units are safe to multiply.
 // Quantity.Multiply is an easier method to use, since it can handle value
 // conversions automatically.
 func (u *Unit) Multiply(o *Unit) *Unit {
+	if u.Logarithmic() || o.Logarithmic() {
+		panic("can't multiply logarithmic units")
+	}
+
 	n := &Unit{}
 	n.dim.Mass = u.dim.Mass + o.dim.Mass
 	n.dim.Length = u.dim.Length + o.dim.Length
@@ -314,6 +410,7 @@ func (u *Unit) Multiply(o *Unit) *Unit {
 	n.dim.Time = u.dim.Time + o.dim.Time
 	n.dim.ElectricCurrent = u.dim.ElectricCurrent + o.dim.ElectricCurrent
 	n.dim.LuminousIntensity = u.dim.LuminousIntensity + o.dim.LuminousIntensity
+	n.dim.Temperature = u.dim.Temperature + o.dim.Temperature
 
 	if !u.SameBaseUnits(o) {
 		panic("can't multiply units with differing base units")
@@ -337,6 +434,9 @@ func (u *Unit) Multiply(o *Unit) *Unit {
 	if u.base.LuminousIntensity != nil {
 		n.base.LuminousIntensity = u.base.LuminousIntensity
 	}
+	if u.base.Temperature != nil {
+		n.base.Temperature = u.base.Temperature
+	}
 
 	if o.base.Mass != nil {
 		n.base.Mass = o.base.Mass
@@ -356,6 +456,33 @@ func (u *Unit) Multiply(o *Unit) *Unit {
 	if o.base.LuminousIntensity != nil {
 		n.base.LuminousIntensity = o.base.LuminousIntensity
 	}
+	if o.base.Temperature != nil {
+		n.base.Temperature = o.base.Temperature
+	}
+
+	// Dimensions that cancel out, such as time in ohm-farads, no longer
+	// need a base unit.
+	if n.dim.Mass == 0 {
+		n.base.Mass = nil
+	}
+	if n.dim.Length == 0 {
+		n.base.Length = nil
+	}
+	if n.dim.Angle == 0 {
+		n.base.Angle = nil
+	}
+	if n.dim.Time == 0 {
+		n.base.Time = nil
+	}
+	if n.dim.ElectricCurrent == 0 {
+		n.base.ElectricCurrent = nil
+	}
+	if n.dim.LuminousIntensity == 0 {
+		n.base.LuminousIntensity = nil
+	}
+	if n.dim.Temperature == 0 {
+		n.base.Temperature = nil
+	}
 
 	n.scale = u.scale
 
@@ -376,7 +503,8 @@ func (u *Unit) Reciprocal() *Unit {
 // units for each dimension or have non-overlapping dimensionality.
 //
 // For derived units that have an associated SI scale factor, this too must
-// match for the result to be true.
+// match for the result to be true, as must the reference of logarithmic
+// units.
 func (u *Unit) SameBaseUnits(o *Unit) bool {
 	if u.base.Mass != o.base.Mass && u.base.Mass != nil && o.base.Mass != nil {
 		return false
@@ -396,6 +524,12 @@ func (u *Unit) SameBaseUnits(o *Unit) bool {
 	if u.base.LuminousIntensity != o.base.LuminousIntensity && u.base.LuminousIntensity != nil && o.base.LuminousIntensity != nil {
 		return false
 	}
+	if u.base.Temperature != o.base.Temperature && u.base.Temperature != nil && o.base.Temperature != nil {
+		return false
+	}
+	if u.base.Level != o.base.Level {
+		return false
+	}
 	if u.scale != o.scale {
 		return false
 	}
@@ -409,6 +543,9 @@ func (u *Unit) SameBaseUnits(o *Unit) bool {
 // a name will be constructed from the set of base units that the given
 // derived unit is built from.
 func (u *Unit) String() string {
+	if u.name != "" {
+		return u.name
+	}
 	if name, hasName := unitName[u]; hasName {
 		return name
 	}
@@ -427,20 +564,25 @@ func (u *Unit) String() string {
 	}
 
 	for _, ei := range e {
+		// Base units of units created by DefineUnit carry their own names,
+		// while all others are in the base tables.
 		var unit *Unit
+		var name string
 		switch ei.Dimension {
 		case Mass:
-			unit = massUnits[u.base.Mass]
+			unit, name = massUnits[u.base.Mass], u.base.Mass.name
 		case Length:
-			unit = lengthUnits[u.base.Length]
+			unit, name = lengthUnits[u.base.Length], u.base.Length.name
 		case Angle:
-			unit = angleUnits[u.base.Angle]
+			unit, name = angleUnits[u.base.Angle], u.base.Angle.name
 		case Time:
-			unit = timeUnits[u.base.Time]
+			unit, name = timeUnits[u.base.Time], u.base.Time.name
 		case ElectricCurrent:
-			unit = electricCurrentUnits[u.base.ElectricCurrent]
+			unit, name = electricCurrentUnits[u.base.ElectricCurrent], u.base.ElectricCurrent.name
 		case LuminousIntensity:
-			unit = luminousIntensityUnits[u.base.LuminousIntensity]
+			unit, name = luminousIntensityUnits[u.base.LuminousIntensity], u.base.LuminousIntensity.name
+		case Temperature:
+			unit, name = temperatureUnits[u.base.Temperature], u.base.Temperature.name
 		default:
 			// should never happen if dimEntries is working correctly
 			panic("String called on Unit with invalid base dimension entry")
@@ -448,8 +590,10 @@ func (u *Unit) String() string {
 
 		// We assume here that all units in the base tables will have names;
 		// if any don't, that's a bug to be fixed.
-		unitName := unitName[unit]
-		buf.WriteString(unitName)
+		if unit != nil {
+			name = unitName[unit]
+		}
+		buf.WriteString(name)
 		if ei.Power != 1 {
 			buf.WriteString(powerReplacer.Replace(strconv.Itoa(ei.Power)))
 		}
@@ -475,5 +619,10 @@ func (u *Unit) GoString() string {
 		return fmt.Sprintf("units.ByName(%q)", name)
 	}
 
+	if u.name != "" {
+		// The base units of a unit created by DefineUnit are its own, so
+		// we omit them in favor of its name.
+		return fmt.Sprintf("&units.Unit{dim: %#v, scale: %#v, name: %q}", u.dim, u.scale, u.name)
+	}
 	return fmt.Sprintf("&units.Unit{dim: %#v, base: %#v, scale: %#v}", u.dim, u.base, u.scale)
 }
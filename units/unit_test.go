@@ -132,6 +132,7 @@ func TestUnitReciprocal(t *testing.T) {
 					LuminousIntensity: candela,
 				},
 				0,
+				"",
 			},
 			"kg m² cd⁻¹ s⁻² A⁻³",
 		},
@@ -191,6 +192,7 @@ func TestUnitString(t *testing.T) {
 				Dimensionality{Mass: 1, Time: 1},
 				baseUnits{Mass: kilogram, Time: second},
 				0,
+				"",
 			},
 			"kg s",
 		},
@@ -199,6 +201,7 @@ func TestUnitString(t *testing.T) {
 				Dimensionality{Length: 1, Time: -2},
 				baseUnits{Length: meter, Time: second},
 				0,
+				"",
 			},
 			"m s⁻²",
 		},
@@ -207,6 +210,7 @@ func TestUnitString(t *testing.T) {
 				Dimensionality{Angle: 1, Time: -2},
 				baseUnits{Angle: degree, Time: second},
 				0,
+				"",
 			},
 			"deg s⁻²",
 		},
@@ -215,6 +219,7 @@ func TestUnitString(t *testing.T) {
 				Dimensionality{Length: 1, Time: -1},
 				baseUnits{Length: inch, Time: microsecond},
 				0,
+				"",
 			},
 			"in us⁻¹",
 		},
@@ -223,6 +228,7 @@ func TestUnitString(t *testing.T) {
 				Dimensionality{Length: 2},
 				baseUnits{Length: centimeter},
 				0,
+				"",
 			},
 			"cm²",
 		},
@@ -231,6 +237,7 @@ func TestUnitString(t *testing.T) {
 				Dimensionality{ElectricCurrent: 12},
 				baseUnits{ElectricCurrent: ampere},
 				0,
+				"",
 			},
 			"A¹²",
 		},
@@ -239,6 +246,7 @@ func TestUnitString(t *testing.T) {
 				Dimensionality{LuminousIntensity: -20},
 				baseUnits{LuminousIntensity: candela},
 				0,
+				"",
 			},
 			"cd⁻²⁰",
 		},
@@ -253,6 +261,7 @@ func TestUnitString(t *testing.T) {
 					LuminousIntensity: candela,
 				},
 				0,
+				"",
 			},
 			"cd s² A³ kg⁻¹ m⁻²",
 		},
@@ -272,3 +281,56 @@ func TestUnitString(t *testing.T) {
 		})
 	}
 }
+
+func TestByNamePrefixed(t *testing.T) {
+	tests := []struct {
+		Name string
+		Want string
+		Dim  string
+	}{
+		{"nF", "nF", "F"},
+		{"pF", "pF", "F"},
+		{"µF", "uF", "F"},
+		{"μF", "uF", "F"},
+		{"nH", "nH", "H"},
+		{"ns", "ns", "s"},
+		{"ps", "ps", "s"},
+		{"µs", "us", "s"},
+		{"uA", "uA", "A"},
+		{"nA", "nA", "A"},
+		{"mohm", "mohm", "ohm"},
+		{"Gohm", "Gohm", "ohm"},
+		{"GHz", "GHz", "Hz"},
+		{"mcd", "mcd", "cd"},
+		{"nm", "nm", "m"},
+		{"mg", "mg", "kg"},
+		{"mrad", "mrad", "deg"},
+		{"kN", "kN", "N"},
+		{"uV", "uV", "V"},
+		{"pC", "pC", "C"},
+		{"mK", "mK", "K"},
+		{"°C", "degC", "K"},
+		{"°F", "degF", "K"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			unit := ByName(test.Name)
+			if unit == nil {
+				t.Fatalf("no unit named %q", test.Name)
+			}
+			if got := unit.String(); got != test.Want {
+				t.Errorf("wrong name %q; want %q", got, test.Want)
+			}
+			if !unit.CommensurableWith(ByName(test.Dim)) {
+				t.Errorf("%s is not commensurable with %s", test.Name, test.Dim)
+			}
+		})
+	}
+
+	for _, name := range []string{"pin", "kin", "nlb", "Mdeg", "kmil", "pkg", "mdegC", "µ"} {
+		if ByName(name) != nil {
+			t.Errorf("%q is a unit, but non-SI units should not be prefixable", name)
+		}
+	}
+}
@@ -37,6 +37,21 @@ var Ampere *Unit
 // Kilogram is equivalent to ByName("cd")
 var Candela *Unit
 
+// Ohm is equivalent to ByName("ohm")
+var Ohm *Unit
+
+// Volt is equivalent to ByName("V")
+var Volt *Unit
+
+// Watt is equivalent to ByName("W")
+var Watt *Unit
+
+// Farad is equivalent to ByName("F")
+var Farad *Unit
+
+// Henry is equivalent to ByName("H")
+var Henry *Unit
+
 // DegreeTenths is a strange unit provided only for use with Quantity.FormatValue
 // when generating angles for Kicad, which represents angles in tenths of a degree.
 //
@@ -53,8 +68,13 @@ func init() {
 	Inch = unitByName["in"]
 	Mil = unitByName["mil"]
 	Degree = unitByName["deg"]
-	DegreeTenths = &Unit{Dimensionality{Angle: 1}, baseUnits{Angle: degree}, -10}
+	DegreeTenths = &Unit{Dimensionality{Angle: 1}, baseUnits{Angle: degree}, -10, ""}
 	Second = unitByName["s"]
 	Ampere = unitByName["A"]
 	Candela = unitByName["cd"]
+	Ohm = unitByName["ohm"]
+	Volt = unitByName["V"]
+	Watt = unitByName["W"]
+	Farad = unitByName["F"]
+	Henry = unitByName["H"]
 }